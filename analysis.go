@@ -0,0 +1,244 @@
+package printer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// interval is a half-open [start, end) span of time.
+type interval struct {
+	start, end time.Time
+}
+
+// mergeIntervals returns the union of ivs as a sorted list of disjoint
+// intervals. Empty and inverted intervals are dropped.
+func mergeIntervals(ivs []interval) []interval {
+	var valid []interval
+	for _, iv := range ivs {
+		if iv.end.After(iv.start) {
+			valid = append(valid, iv)
+		}
+	}
+	sort.Slice(valid, func(i, j int) bool {
+		return valid[i].start.Before(valid[j].start)
+	})
+
+	var merged []interval
+	for _, iv := range valid {
+		if n := len(merged); n > 0 && !iv.start.After(merged[n-1].end) {
+			if iv.end.After(merged[n-1].end) {
+				merged[n-1].end = iv.end
+			}
+			continue
+		}
+		merged = append(merged, iv)
+	}
+	return merged
+}
+
+// selfTime returns the portion of span's duration not covered by any of its
+// children. Child intervals are clipped to the span's window and merged
+// before being subtracted, so overlapping children are only counted once.
+func selfTime(span tracetest.SpanStub, children []tracetest.SpanStub) time.Duration {
+	total := span.EndTime.Sub(span.StartTime)
+	if total <= 0 {
+		return 0
+	}
+
+	ivs := make([]interval, 0, len(children))
+	for _, child := range children {
+		iv := interval{start: child.StartTime, end: child.EndTime}
+		if iv.start.Before(span.StartTime) {
+			iv.start = span.StartTime
+		}
+		if iv.end.After(span.EndTime) {
+			iv.end = span.EndTime
+		}
+		ivs = append(ivs, iv)
+	}
+
+	var covered time.Duration
+	for _, iv := range mergeIntervals(ivs) {
+		covered += iv.end.Sub(iv.start)
+	}
+	return total - covered
+}
+
+// spanDuration returns span's duration, or false when it has none: when it
+// hasn't ended, its start is unset, or it ends before it starts.
+func spanDuration(span tracetest.SpanStub) (time.Duration, bool) {
+	if span.StartTime.IsZero() || span.EndTime.IsZero() || span.EndTime.Before(span.StartTime) {
+		return 0, false
+	}
+	return span.EndTime.Sub(span.StartTime), true
+}
+
+// wallClock returns the time between the earliest start and the latest end
+// across spans, ignoring unset times. It is zero when either is unknown.
+func wallClock(spans []tracetest.SpanStub) time.Duration {
+	start := earliestStart(spans)
+	var end time.Time
+	for _, s := range spans {
+		if s.EndTime.After(end) {
+			end = s.EndTime
+		}
+	}
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// earliestStart returns the earliest start time across spans, ignoring
+// unset start times. It returns the zero time when no span has a start time.
+func earliestStart(spans []tracetest.SpanStub) time.Time {
+	var start time.Time
+	for _, s := range spans {
+		if !s.StartTime.IsZero() && (start.IsZero() || s.StartTime.Before(start)) {
+			start = s.StartTime
+		}
+	}
+	return start
+}
+
+// criticalPath returns the root-to-leaf chain below roots that accounts for
+// the most time, along with that time.
+//
+// Each span on a chain contributes its self time, so a chain's total is the
+// time the trace spent in exactly those spans. When siblings run in parallel
+// the chain through the longest one covers nearly the whole trace; when they
+// run one after another, no single chain does, and the gap between the
+// critical path and the trace's wall-clock total shows how much work was
+// serialized.
+func (t *spanTree) criticalPath(roots []tracetest.SpanStub) ([]tracetest.SpanStub, time.Duration) {
+	if len(roots) == 0 {
+		return nil, 0
+	}
+
+	// Record each span's best child rather than building paths on the way
+	// up, which would copy every path once per level.
+	next := make(map[string]tracetest.SpanStub)
+
+	var best func(span tracetest.SpanStub) time.Duration
+	best = func(span tracetest.SpanStub) time.Duration {
+		children := t.childrenOf(span)
+
+		var length time.Duration
+		for i, child := range children {
+			if l := best(child); i == 0 || l > length {
+				next[span.SpanContext.SpanID().String()] = child
+				length = l
+			}
+		}
+		return selfTime(span, children) + length
+	}
+
+	var start tracetest.SpanStub
+	var length time.Duration
+	for i, root := range roots {
+		if l := best(root); i == 0 || l > length {
+			start, length = root, l
+		}
+	}
+	path := []tracetest.SpanStub{start}
+	for {
+		child, ok := next[path[len(path)-1].SpanContext.SpanID().String()]
+		if !ok {
+			break
+		}
+		path = append(path, child)
+	}
+	return path, length
+}
+
+// errorTimeShares returns, keyed by SpanID, the fraction of the total
+// duration of the error spans below roots that each of them accounts for.
+func (t *spanTree) errorTimeShares(cfg *Config, roots []tracetest.SpanStub) map[string]float64 {
+	var failed []tracetest.SpanStub
+	var total time.Duration
+	for _, s := range t.descendants(roots) {
+		if d, ok := spanDuration(s); ok && isErrorSpan(cfg, s) && d > 0 {
+			failed = append(failed, s)
+			total += d
+		}
+	}
+
+	shares := make(map[string]float64, len(failed))
+	for _, s := range failed {
+		d, _ := spanDuration(s)
+		shares[s.SpanContext.SpanID().String()] = float64(d) / float64(total)
+	}
+	return shares
+}
+
+// truncationReasons explains why the trace formed by roots looks like it was
+// captured mid-flight, or returns nil when it looks complete. A trace looks
+// truncated when a descendant ends after its root, or when spans in the
+// trace reference a parent that isn't present. Missing parents are named as
+// in the span boxes, or counted together when cfg.HideIDs is set.
+func (t *spanTree) truncationReasons(cfg *Config, roots []tracetest.SpanStub) []string {
+	if len(roots) == 0 {
+		return nil
+	}
+
+	var reasons []string
+	for _, root := range roots {
+		var latest *tracetest.SpanStub
+		for _, s := range t.descendants([]tracetest.SpanStub{root})[1:] {
+			if s.EndTime.After(root.EndTime) && (latest == nil || s.EndTime.After(latest.EndTime)) {
+				latest = &s
+			}
+		}
+		if latest != nil {
+			reasons = append(reasons, fmt.Sprintf("span %q ends after root %q", latest.Name, root.Name))
+		}
+	}
+
+	traceID := roots[0].SpanContext.TraceID()
+	missing := make(map[string]int)
+	for _, s := range t.byID {
+		if s.SpanContext.TraceID() != traceID || !s.Parent.SpanID().IsValid() {
+			continue
+		}
+		parentID := s.Parent.SpanID().String()
+		if _, ok := t.byID[parentID]; !ok {
+			missing[parentID]++
+		}
+	}
+	if len(missing) == 0 {
+		return reasons
+	}
+
+	if cfg.HideIDs {
+		var total int
+		for _, n := range missing {
+			total += n
+		}
+		reasons = append(reasons, fmt.Sprintf("%s %s %s",
+			plural(total, "span", "spans"), references(total), plural(len(missing), "missing parent", "missing parents")))
+		return reasons
+	}
+
+	parentIDs := make([]string, 0, len(missing))
+	for id := range missing {
+		parentIDs = append(parentIDs, id)
+	}
+	sort.Strings(parentIDs)
+	for _, id := range parentIDs {
+		reasons = append(reasons, fmt.Sprintf("%s %s missing parent %s",
+			plural(missing[id], "span", "spans"), references(missing[id]), displayID(cfg, id)))
+	}
+
+	return reasons
+}
+
+// references returns the form of "reference" that agrees with n spans.
+func references(n int) string {
+	if n == 1 {
+		return "references"
+	}
+	return "reference"
+}
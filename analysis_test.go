@@ -0,0 +1,245 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTree_CriticalPath(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 220*ms),
+		newSpan("fetch", 2, 1, 0, 100*ms),
+		newSpan("process", 3, 1, 100*ms, 180*ms),
+		newSpan("process.inner", 4, 3, 100*ms, 180*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
+	must.StrNotContains(t, buf.String(), "critical path:")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithCriticalPath(true)))
+
+	// root contributes 40ms of self time, fetch 100ms: the root → fetch
+	// chain beats root → process → process.inner (40ms + 0 + 80ms).
+	must.StrContains(t, buf.String(), "critical path: 140ms (of 220ms total)")
+}
+
+func TestPrintSpanTree_Summary(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 220*ms),
+		newSpan("fetch", 2, 1, 0, 100*ms),
+		newSpan("process", 3, 1, 100*ms, 210*ms),
+		newSpan("process.inner", 4, 3, 100*ms, 130*ms),
+	}
+	spans[3].Status = sdktrace.Status{Code: codes.Error}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
+	must.StrNotContains(t, buf.String(), "summary:")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithSummary(true)))
+
+	// process runs longer than fetch, but 30ms of that is spent waiting on
+	// process.inner, leaving fetch with the most self time.
+	must.StrContains(t, buf.String(), "summary: 4 spans, 1 error, 220ms total, slowest 'fetch' (100ms self)")
+}
+
+func TestPrintSpanTree_TruncationDetection(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("quick", 2, 1, 10*ms, 20*ms),
+		newSpan("straggler", 3, 1, 50*ms, 150*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithTruncationDetection(true)))
+	must.StrContains(t, buf.String(), `⚠ trace may be truncated: span "straggler" ends after root "root"`)
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans[:2], printer.WithTruncationDetection(true)))
+	must.StrNotContains(t, buf.String(), "truncated")
+
+	buf.Reset()
+	orphan := newSpan("orphan", 4, 9, 10*ms, 20*ms)
+	must.NoError(t, printer.PrintSpanTree(&buf, append(spans[:2], orphan), printer.WithTruncationDetection(true)))
+	must.StrContains(t, buf.String(), "⚠ trace may be truncated: 1 span references missing parent 0000000000000009")
+
+	// The missing parents' IDs follow ShortIDs and HideIDs
+	stray := newSpan("stray", 5, 9, 10*ms, 20*ms)
+	withOrphans := append(spans[:2], orphan, stray, newSpan("lost", 6, 8, 10*ms, 20*ms))
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, withOrphans, printer.WithTruncationDetection(true)))
+	must.StrContains(t, buf.String(), "⚠ trace may be truncated: 2 spans reference missing parent 0000000000000009")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, withOrphans, printer.WithTruncationDetection(true), printer.WithShortIDs(true)))
+	must.StrContains(t, buf.String(), "1 span references missing parent …00000008")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, withOrphans, printer.WithTruncationDetection(true), printer.WithSnapshotMode()))
+	must.StrContains(t, buf.String(), "⚠ trace may be truncated: 3 spans reference 2 missing parents")
+	must.StrNotContains(t, buf.String(), "00000009")
+}
+
+func TestPrintSpanTree_FanOutSummary(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("a", 2, 1, 0, 10*ms),
+		newSpan("b", 3, 1, 10*ms, 20*ms),
+		newSpan("c", 4, 1, 20*ms, 30*ms),
+		newSpan("c.inner", 5, 4, 20*ms, 25*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithFanOutSummary(true)))
+
+	// root has three children and c has one: (3 + 1) / 2 parents.
+	must.StrContains(t, buf.String(), "max fan-out: 3, avg fan-out: 2.0")
+}
+
+func TestPrintSpanTree_DepthHistogram(t *testing.T) {
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(), printer.WithDepthHistogram(true)))
+
+	// root-span, its two children, and child-span-3 beneath child-span-2.
+	must.StrContains(t, buf.String(), "depth histogram: L0=1 L1=2 L2=1")
+}
+
+func TestPrintSpanTree_EventBookends(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("child", 2, 1, 10*ms, 90*ms),
+	}
+	spans[0].Events = []sdktrace.Event{
+		{Name: "request.start", Time: baseTime.Add(ms)},
+		{Name: "response.sent", Time: baseTime.Add(99 * ms)},
+	}
+	spans[1].Events = []sdktrace.Event{
+		{Name: "cache.miss", Time: baseTime.Add(20 * ms)},
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithEventBookends(true)))
+
+	must.StrContains(t, buf.String(), `first event: "request.start" at 2024-01-02 15:04:05.001 UTC; last: "response.sent" at 2024-01-02 15:04:05.099 UTC`)
+}
+
+func TestPrintSpanTree_Efficiency(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("handler", 1, 0, 0, 100*ms),
+		newSpan("db", 2, 1, 10*ms, 50*ms),
+		newSpan("cache", 3, 1, 40*ms, 70*ms),
+		newSpan("instant", 4, 1, 80*ms, 80*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithEfficiency(true)))
+	output := buf.String()
+
+	// Children cover 10ms–70ms, leaving 40ms of the handler's 100ms.
+	must.StrContains(t, output, "Efficiency:  40%")
+	must.StrContains(t, output, "Efficiency:  100%")
+	must.Eq(t, 3, strings.Count(output, "Efficiency:"))
+}
+
+func TestPrintSpanTree_SelfTime(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("handler", 1, 0, 0, 100*ms),
+		newSpan("db", 2, 1, 10*ms, 60*ms),
+		newSpan("cache", 3, 1, 30*ms, 75*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithSelfTime(true)))
+	output := buf.String()
+
+	// The children overlap from 30ms to 60ms, so together they cover
+	// 10ms–75ms: 65ms, not the 95ms their durations add up to.
+	must.Eq(t, 1, strings.Count(output, "Self Time:  35.0ms"))
+	must.Less(t, strings.Index(output, "Self Time:  35.0ms"), strings.Index(output, "Duration:  100ms"))
+	must.StrContains(t, output, "Self Time:  50.0ms")
+	must.StrContains(t, output, "Self Time:  45.0ms")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
+	must.StrNotContains(t, buf.String(), "Self Time:")
+}
+
+func TestPrintSpanTree_ErrorTimeShare(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("checkout", 1, 0, 0, 500*ms),
+		newSpan("inventory", 2, 1, 0, 300*ms),
+		newSpan("payment", 3, 1, 300*ms, 400*ms),
+		newSpan("email", 4, 1, 400*ms, 500*ms),
+	}
+	spans[1].Status = sdktrace.Status{Code: codes.Error}
+	spans[2].Status = sdktrace.Status{Code: codes.Error}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithErrorTimeShare(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "300ms (75% of error time)")
+	must.StrContains(t, output, "100ms (25% of error time)")
+	must.Eq(t, 2, strings.Count(output, "of error time"))
+}
+
+func TestPrintSpanTree_CriticalPathHighlight(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 300*ms),
+		newSpan("slow", 2, 1, 0, 250*ms),
+		newSpan("slow.db", 3, 2, 10*ms, 240*ms),
+		newSpan("fast", 4, 1, 250*ms, 260*ms),
+		newSpan("fast.cache", 5, 4, 251*ms, 252*ms),
+		newSpan("other-root", 6, 0, 0, 50*ms),
+	}
+
+	// borderOf returns the border drawn to the left of name's Span Name
+	// line, which belongs to name's own box.
+	borderOf := func(output, name string) string {
+		for _, line := range strings.Split(output, "\n") {
+			if i := strings.Index(line, " Span Name:  "+name+" "); i >= 0 {
+				border := []rune(strings.TrimRight(line[:i], " "))
+				return string(border[len(border)-1])
+			}
+		}
+		t.Fatalf("%q not found", name)
+		return ""
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
+	must.StrNotContains(t, buf.String(), "┃")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithCriticalPath(true)))
+	output := buf.String()
+
+	for _, name := range []string{"root", "slow", "slow.db"} {
+		must.Eq(t, "┃", borderOf(output, name), must.Sprint(name))
+	}
+	for _, name := range []string{"fast", "fast.cache", "other-root"} {
+		must.Eq(t, "│", borderOf(output, name), must.Sprint(name))
+	}
+}
@@ -0,0 +1,201 @@
+package printer
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Semantic attribute types understood by WithSemanticAttributes.
+const (
+	semanticDurationNanos  = "duration-ns"
+	semanticTimestampNanos = "timestamp-ns"
+	semanticTimestampMilli = "timestamp-ms"
+)
+
+// redactedValue replaces the values of redacted attributes.
+const redactedValue = "«redacted»"
+
+// defaultRedactedKeys name the attributes that commonly carry credentials.
+var defaultRedactedKeys = []string{
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+	"password",
+	"passwd",
+	"secret",
+	"client_secret",
+	"token",
+	"access_token",
+	"refresh_token",
+	"api_key",
+	"apikey",
+	"x-api-key",
+	"private_key",
+}
+
+// DefaultRedactedKeys returns the keys redacted when WithRedactedKeys isn't
+// used, for extending with keys of your own.
+func DefaultRedactedKeys() []string {
+	return slices.Clone(defaultRedactedKeys)
+}
+
+// formatAttributeValue renders attr's value for display, applying the value
+// formatting enabled in cfg. The values of redacted keys are replaced.
+func formatAttributeValue(cfg *Config, attr attribute.KeyValue) string {
+	if isRedacted(cfg.RedactedKeys, string(attr.Key)) {
+		return redactedValue
+	}
+	if formatted, ok := formatSemanticValue(cfg, cfg.SemanticAttributes[string(attr.Key)], attr.Value); ok {
+		return formatted
+	}
+	if formatted, ok := formatSliceValue(attr.Value); ok {
+		return formatted
+	}
+	if cfg.ThousandsSeparator && !looksLikeID(string(attr.Key)) {
+		switch attr.Value.Type() {
+		case attribute.INT64:
+			return groupThousands(strconv.FormatInt(attr.Value.AsInt64(), 10))
+		case attribute.FLOAT64:
+			if f := attr.Value.AsFloat64(); !math.IsInf(f, 0) && !math.IsNaN(f) {
+				return groupThousands(strconv.FormatFloat(f, 'f', -1, 64))
+			}
+		}
+	}
+	return fmt.Sprintf("%v", attr.Value.AsInterface())
+}
+
+// formatSliceValue renders a slice value as its comma-separated elements
+// followed by the element type, e.g. "[a, b, c] (string[])", or returns
+// false when v isn't a slice.
+func formatSliceValue(v attribute.Value) (string, bool) {
+	var elems []string
+	var typ string
+	switch v.Type() {
+	case attribute.BOOLSLICE:
+		for _, b := range v.AsBoolSlice() {
+			elems = append(elems, strconv.FormatBool(b))
+		}
+		typ = "bool"
+	case attribute.INT64SLICE:
+		for _, n := range v.AsInt64Slice() {
+			elems = append(elems, strconv.FormatInt(n, 10))
+		}
+		typ = "int64"
+	case attribute.FLOAT64SLICE:
+		for _, f := range v.AsFloat64Slice() {
+			elems = append(elems, strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		typ = "float64"
+	case attribute.STRINGSLICE:
+		elems = v.AsStringSlice()
+		typ = "string"
+	default:
+		return "", false
+	}
+	return fmt.Sprintf("[%s] (%s[])", strings.Join(elems, ", "), typ), true
+}
+
+// isRedacted reports whether key matches one of redacted, either exactly or
+// by its last dot-separated segment, so "password" also matches
+// "user.password" and "authorization" matches
+// "http.request.header.authorization". Matching ignores case.
+func isRedacted(redacted []string, key string) bool {
+	last := key[strings.LastIndexByte(key, '.')+1:]
+	for _, r := range redacted {
+		if strings.EqualFold(key, r) || strings.EqualFold(last, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedAttributes returns attrs sorted by key when cfg.AttributeSort is set,
+// or attrs unchanged otherwise.
+func sortedAttributes(cfg *Config, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if !cfg.AttributeSort {
+		return attrs
+	}
+	sorted := slices.Clone(attrs)
+	slices.SortStableFunc(sorted, func(a, b attribute.KeyValue) int {
+		return strings.Compare(string(a.Key), string(b.Key))
+	})
+	return sorted
+}
+
+// filterAttributes returns the attributes of attrs whose keys are in keys,
+// in the order of keys.
+func filterAttributes(attrs []attribute.KeyValue, keys []string) []attribute.KeyValue {
+	var filtered []attribute.KeyValue
+	for i, key := range keys {
+		if slices.Contains(keys[:i], key) {
+			continue
+		}
+		for _, attr := range attrs {
+			if string(attr.Key) == key {
+				filtered = append(filtered, attr)
+			}
+		}
+	}
+	return filtered
+}
+
+// formatSemanticValue renders an integer value as the semantic type typ, or
+// returns false when typ is unknown or the value isn't an integer.
+func formatSemanticValue(cfg *Config, typ string, v attribute.Value) (string, bool) {
+	if v.Type() != attribute.INT64 {
+		return "", false
+	}
+	n := v.AsInt64()
+	switch typ {
+	case semanticDurationNanos:
+		return formatDuration(cfg, time.Duration(n)), true
+	case semanticTimestampNanos:
+		return formatTime(cfg, time.Unix(0, n).UTC()), true
+	case semanticTimestampMilli:
+		return formatTime(cfg, time.UnixMilli(n).UTC()), true
+	default:
+		return "", false
+	}
+}
+
+// looksLikeID reports whether key names an identifier, such as "user.id",
+// "request_id", or "traceID", whose digits shouldn't be grouped.
+func looksLikeID(key string) bool {
+	lower := strings.ToLower(key)
+	return lower == "id" ||
+		strings.HasSuffix(lower, ".id") ||
+		strings.HasSuffix(lower, "_id") ||
+		strings.HasSuffix(lower, "-id") ||
+		strings.HasSuffix(key, "ID") ||
+		strings.HasSuffix(key, "Id")
+}
+
+// groupThousands inserts commas between each group of three digits in the
+// integer part of the decimal number s, e.g. "-1048576.5" → "-1,048,576.5".
+func groupThousands(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String() + frac
+}
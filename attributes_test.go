@@ -0,0 +1,161 @@
+package printer_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTree_ThousandsSeparator(t *testing.T) {
+	span := newSpan("upload", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.Int("http.request.body.size", 1048576),
+		attribute.Float64("throughput", -12345.5),
+		attribute.Int("user.id", 1048576),
+		attribute.String("checksum", "1048576"),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithThousandsSeparator(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "• http.request.body.size = 1,048,576")
+	must.StrContains(t, output, "• throughput = -12,345.5")
+	must.StrContains(t, output, "• user.id = 1048576")
+	must.StrContains(t, output, "• checksum = 1048576")
+}
+
+func TestPrintSpanTree_SliceAttributes(t *testing.T) {
+	span := newSpan("request", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.StringSlice("http.request.header.accept", []string{"text/html", "application/json"}),
+		attribute.Int64Slice("retry.delays", []int64{100, 200, 400}),
+		attribute.BoolSlice("flags", []bool{true, false}),
+		attribute.Float64Slice("ratios", []float64{0.5, 1.25}),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}))
+	output := buf.String()
+
+	must.StrContains(t, output, "• http.request.header.accept = [text/html, application/json] (string[])")
+	must.StrContains(t, output, "• retry.delays = [100, 200, 400] (int64[])")
+	must.StrContains(t, output, "• flags = [true, false] (bool[])")
+	must.StrContains(t, output, "• ratios = [0.5, 1.25] (float64[])")
+}
+
+func TestPrintSpanTree_SemanticAttributes(t *testing.T) {
+	span := newSpan("cache.get", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.Int64("cache.ttl", 250000000),
+		attribute.Int64("cache.expires_at", baseTime.UnixMilli()),
+		attribute.Int64("cache.size", 250000000),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithSemanticAttributes(map[string]string{
+		"cache.ttl":        "duration-ns",
+		"cache.expires_at": "timestamp-ms",
+	})))
+	output := buf.String()
+
+	must.StrContains(t, output, "• cache.ttl = 250ms")
+	must.StrContains(t, output, "• cache.expires_at = 2024-01-02 15:04:05.000 UTC")
+	must.StrContains(t, output, "• cache.size = 250000000")
+}
+
+func TestPrintSpanTree_AttributeDepthLimit(t *testing.T) {
+	spans := sampleSpans()
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithAttributeDepthLimit(1)))
+	output := buf.String()
+
+	must.StrContains(t, output, "• component = root")
+	must.StrContains(t, output, "• error_code = something_wrong")
+	must.StrNotContains(t, output, "• component = child-3")
+	must.StrContains(t, output, "(1 attributes)")
+}
+
+func TestPrintSpanTree_AttributeSort(t *testing.T) {
+	span := newSpan("request", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.String("url.path", "/cart"),
+		attribute.Int("http.response.status_code", 200),
+		attribute.String("server.address", "shop.local"),
+	}
+
+	var sorted, recorded bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&sorted, []tracetest.SpanStub{span}))
+	must.NoError(t, printer.PrintSpanTree(&recorded, []tracetest.SpanStub{span}, printer.WithAttributeSort(false)))
+
+	order := func(output string) []int {
+		return []int{
+			strings.Index(output, "http.response.status_code"),
+			strings.Index(output, "server.address"),
+			strings.Index(output, "url.path"),
+		}
+	}
+	must.True(t, slices.IsSorted(order(sorted.String())))
+	must.False(t, slices.IsSorted(order(recorded.String())))
+	must.True(t, strings.Index(recorded.String(), "url.path") < strings.Index(recorded.String(), "http.response.status_code"))
+}
+
+func TestPrintSpanTree_AttributeFilter(t *testing.T) {
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(), printer.WithAttributeFilter("component")))
+	output := buf.String()
+
+	must.StrContains(t, output, "• component = root")
+	must.StrContains(t, output, "• component = child-3")
+	must.StrNotContains(t, output, "error_code")
+
+	span := newSpan("request", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.String("a", "1"),
+		attribute.String("b", "2"),
+		attribute.String("c", "3"),
+	}
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithAttributeFilter("c", "a")))
+	output = buf.String()
+
+	must.True(t, strings.Index(output, "• c = 3") < strings.Index(output, "• a = 1"))
+	must.StrNotContains(t, output, "• b = 2")
+}
+
+func TestPrintSpanTree_RedactedKeys(t *testing.T) {
+	span := newSpan("login", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.String("password", "hunter2"),
+		attribute.String("http.request.header.authorization", "Bearer abc123"),
+		attribute.String("db.statement", "SELECT * FROM users WHERE email = 'a@b.c'"),
+		attribute.String("user.name", "ada"),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}))
+	output := buf.String()
+
+	must.StrContains(t, output, "• password = «redacted»")
+	must.StrNotContains(t, output, "hunter2")
+	must.StrContains(t, output, "• http.request.header.authorization = «redacted»")
+	must.StrContains(t, output, "a@b.c")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span},
+		printer.WithRedactedKeys(append(printer.DefaultRedactedKeys(), "db.statement")...)))
+	output = buf.String()
+
+	must.StrContains(t, output, "• db.statement = «redacted»")
+	must.StrNotContains(t, output, "hunter2")
+	must.StrContains(t, output, "• user.name = ada")
+}
@@ -0,0 +1,100 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	// expandedGlyph marks a span whose children are shown.
+	expandedGlyph = "▾"
+
+	// collapsedGlyph marks a span whose children are hidden.
+	collapsedGlyph = "▸"
+)
+
+// CollapsibleTree is a span tree whose subtrees can be collapsed and
+// expanded between renders, for interactive views such as TUIs. Spans are
+// identified by their hex-encoded SpanID, as returned by
+// trace.SpanID.String.
+//
+// A CollapsibleTree is not safe for concurrent use.
+type CollapsibleTree struct {
+	cfg       *Config
+	tree      *spanTree
+	collapsed map[string]bool
+}
+
+// NewCollapsibleTree builds a CollapsibleTree from spans with every span
+// expanded.
+func NewCollapsibleTree(spans []tracetest.SpanStub, opts ...Option) *CollapsibleTree {
+	cfg := newConfig(opts...)
+	return &CollapsibleTree{
+		cfg:       cfg,
+		tree:      newSpanTree(cfg, filterSpans(cfg, spans)),
+		collapsed: make(map[string]bool),
+	}
+}
+
+// Toggle collapses the span if it is expanded, and expands it otherwise.
+// Unknown span IDs are ignored.
+func (c *CollapsibleTree) Toggle(spanID string) {
+	if c.collapsed[spanID] {
+		c.Expand(spanID)
+	} else {
+		c.Collapse(spanID)
+	}
+}
+
+// Expand shows the children of the span. Unknown span IDs are ignored.
+func (c *CollapsibleTree) Expand(spanID string) {
+	delete(c.collapsed, spanID)
+}
+
+// Collapse hides the subtree below the span. Unknown span IDs are ignored.
+func (c *CollapsibleTree) Collapse(spanID string) {
+	if _, ok := c.tree.byID[spanID]; ok {
+		c.collapsed[spanID] = true
+	}
+}
+
+// Render returns the tree with one line per visible span, nested by
+// indentation. Spans with children are marked ▾ when expanded and ▸ when
+// collapsed, and a collapsed span notes how many spans it hides, e.g.
+// "(3 hidden)".
+func (c *CollapsibleTree) Render() string {
+	cfg := c.cfg
+
+	var lines []string
+	var walk func(span tracetest.SpanStub, depth int)
+	walk = func(span tracetest.SpanStub, depth int) {
+		id := span.SpanContext.SpanID().String()
+		children := c.tree.childrenOf(span)
+
+		glyph := " "
+		if len(children) > 0 {
+			glyph = expandedGlyph
+			if c.collapsed[id] {
+				glyph = collapsedGlyph
+			}
+		}
+
+		entry := fmt.Sprintf("%s %s (%s)", glyph, span.Name, formatSpanDuration(cfg, span))
+		if len(children) > 0 && c.collapsed[id] {
+			entry += fmt.Sprintf(" (%d hidden)", len(c.tree.descendants(children)))
+			children = nil
+		}
+		lines = append(lines, strings.Repeat(cfg.Indent, depth)+cfg.styles.value.Render(entry))
+
+		for _, child := range children {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range c.tree.roots {
+		walk(root, 0)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,37 @@
+package printer_test
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestCollapsibleTree(t *testing.T) {
+	spans := sampleSpans()
+	root := spans[0].SpanContext.SpanID().String()
+	child2 := spans[2].SpanContext.SpanID().String()
+
+	tree := printer.NewCollapsibleTree(spans)
+	must.StrContains(t, tree.Render(), "child-span-3")
+
+	tree.Collapse(child2)
+	output := tree.Render()
+	must.StrContains(t, output, "▸ child-span-2")
+	must.StrContains(t, output, "(1 hidden)")
+	must.StrNotContains(t, output, "child-span-3")
+	must.StrContains(t, output, "child-span-1")
+
+	tree.Toggle(root)
+	output = tree.Render()
+	must.StrContains(t, output, "(3 hidden)")
+	must.StrNotContains(t, output, "child-span-1")
+
+	tree.Toggle(root)
+	tree.Expand(child2)
+	output = tree.Render()
+	must.StrContains(t, output, "▾ child-span-2")
+	must.StrContains(t, output, "child-span-3")
+	must.StrNotContains(t, output, "hidden")
+}
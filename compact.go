@@ -0,0 +1,57 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// PrintSpanTreeCompact writes spans to w as one line per span, in the same
+// order as PrintSpanTree, with nesting shown by indentation rather than
+// boxes. Each line has the span's name and duration, followed by its status
+// unless it is Unset with no description. Error spans are highlighted.
+//
+// It returns the first error encountered writing to w.
+func PrintSpanTreeCompact(w io.Writer, spans []tracetest.SpanStub, opts ...Option) error {
+	return NewPrinter(opts...).PrintCompact(w, spans)
+}
+
+// PrintCompact writes spans to w as PrintSpanTreeCompact does, using p's
+// configuration.
+func (p *Printer) PrintCompact(w io.Writer, spans []tracetest.SpanStub) error {
+	cfg := writerConfig(p.cfg, w)
+	tree := newSpanTree(cfg, filterSpans(cfg, spans))
+	out := &lineWriter{w: w}
+
+	var walk func(span tracetest.SpanStub, depth int)
+	walk = func(span tracetest.SpanStub, depth int) {
+		entry := fmt.Sprintf("%s (%s)", span.Name, formatSpanDuration(cfg, span))
+		if status := span.Status; status.Code != codes.Unset || status.Description != "" {
+			entry += " " + status.Code.String()
+			if status.Description != "" {
+				entry += ": " + status.Description
+			}
+		}
+
+		style := cfg.styles.value
+		if isErrorSpan(cfg, span) {
+			style = cfg.styles.errorHighlight
+		}
+		out.println(strings.Repeat(cfg.Indent, depth) + style.Render(entry))
+
+		for _, child := range tree.childrenOf(span) {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range tree.roots {
+		if out.err != nil {
+			break
+		}
+		walk(root, 0)
+	}
+
+	return out.err
+}
@@ -0,0 +1,65 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTreeCompact(t *testing.T) {
+	spans := sampleSpans()
+	spans[3].Status = sdktrace.Status{Code: codes.Error, Description: "timeout"}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeCompact(&buf, spans))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	must.SliceLen(t, 4, lines)
+
+	indent := func(name string) int {
+		for _, line := range lines {
+			if strings.Contains(line, name+" (") {
+				return len(line) - len(strings.TrimLeft(line, " "))
+			}
+		}
+		t.Fatalf("%q not found", name)
+		return 0
+	}
+
+	for _, s := range spans {
+		must.Eq(t, 1, strings.Count(buf.String(), s.Name+" ("))
+	}
+	must.True(t, strings.HasPrefix(lines[0], "root-span ("))
+	must.True(t, indent("root-span") < indent("child-span-1"))
+	must.Eq(t, indent("child-span-1"), indent("child-span-2"))
+	must.True(t, indent("child-span-2") < indent("child-span-3"))
+	must.StrContains(t, buf.String(), "Error: timeout")
+}
+
+func TestPrintSpanTreeCompact_UnknownDurations(t *testing.T) {
+	ms := time.Millisecond
+	pending := newSpan("pending", 2, 1, 10*ms, 0)
+	pending.EndTime = time.Time{}
+	inverted := newSpan("inverted", 3, 1, 30*ms, 20*ms)
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		pending,
+		inverted,
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeCompact(&buf, spans))
+
+	must.Eq(t, ""+
+		"root (100ms)\n"+
+		"  pending (unfinished)\n"+
+		"  inverted (invalid: end before start)\n",
+		buf.String())
+}
@@ -0,0 +1,78 @@
+package printer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// RenderComparison returns a table comparing several captures of the same
+// scenario, such as repeated load-test runs. Each row is a span, matched
+// across captures by canonical key (see RenderDiff), and each column is a
+// capture, sorted by name, showing that span's duration in the capture or
+// "-" when it's absent.
+func RenderComparison(captures map[string][]tracetest.SpanStub, opts ...Option) string {
+	cfg := newConfig(opts...)
+
+	names := make([]string, 0, len(captures))
+	for name := range captures {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	// Collect every key in first-seen order across captures
+	var keys []string
+	seen := make(map[string]bool)
+	columns := make([]map[string]tracetest.SpanStub, len(names))
+	for i, name := range names {
+		order, byKey := keyedSpans(newSpanTree(cfg, filterSpans(cfg, captures[name])))
+		columns[i] = byKey
+		for _, key := range order {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	rows := [][]string{append([]string{"span"}, names...)}
+	for _, key := range keys {
+		row := []string{key}
+		for _, byKey := range columns {
+			cell := "-"
+			if s, ok := byKey[key]; ok {
+				cell = formatSpanDuration(cfg, s)
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	lines := make([]string, len(rows))
+	for r, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			padded := cell + strings.Repeat(" ", widths[i]-lipgloss.Width(cell))
+			if i > 0 {
+				// Right-align durations so magnitudes line up
+				padded = strings.Repeat(" ", widths[i]-lipgloss.Width(cell)) + cell
+			}
+			style := cfg.styles.value
+			if r == 0 {
+				style = cfg.styles.label
+			}
+			cells[i] = style.Render(padded)
+		}
+		lines[r] = strings.TrimRight(strings.Join(cells, "  "), " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,33 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderComparison(t *testing.T) {
+	first := sampleSpans()
+	second := relabel(first, time.Hour, 0x11)
+	second[3].EndTime = second[3].EndTime.Add(2 * time.Second)
+
+	output := printer.RenderComparison(map[string][]tracetest.SpanStub{
+		"run-1": first,
+		"run-2": second,
+	})
+	lines := strings.Split(output, "\n")
+
+	must.SliceLen(t, 1+len(first), lines)
+	must.Eq(t, []string{"span", "run-1", "run-2"}, strings.Fields(lines[0]))
+
+	for _, line := range lines[1:] {
+		must.SliceLen(t, 3, strings.Fields(line))
+	}
+	must.StrHasPrefix(t, "root-span/child-span-2/child-span-3 ", lines[4])
+	must.StrContains(t, lines[4], "2.3")
+}
@@ -0,0 +1,57 @@
+package printer_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTreeCSV(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("checkout", 1, 0, 0, 30*ms),
+		newSpan("email", 4, 1, 25*ms, 29*ms),
+		newSpan("charge", 2, 1, 5*ms, 25*ms),
+		newSpan("card.verify", 3, 2, 6*ms, 10*ms),
+	}
+	spans[0].SpanKind = trace.SpanKindServer
+	spans[2].Status = sdktrace.Status{Code: codes.Error, Description: "declined, retry later"}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeCSV(&buf, spans))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	must.NoError(t, err)
+	must.SliceLen(t, len(spans)+1, records)
+
+	must.Eq(t, []string{
+		"trace_id", "span_id", "parent_id", "name", "kind",
+		"start", "end", "duration_ns", "status_code", "status_message",
+	}, records[0])
+
+	var names []string
+	for _, record := range records[1:] {
+		names = append(names, record[3])
+	}
+	must.Eq(t, []string{"checkout", "charge", "card.verify", "email"}, names)
+
+	must.Eq(t, "", records[1][2])
+	must.Eq(t, "server", records[1][4])
+	must.Eq(t, "20000000", records[2][7])
+	must.Eq(t, spans[0].SpanContext.SpanID().String(), records[2][2])
+	must.Eq(t, "Error", records[2][8])
+	must.Eq(t, "declined, retry later", records[2][9])
+}
+
+func TestPrintSpanTreeCSV_WriteError(t *testing.T) {
+	must.ErrorContains(t, printer.PrintSpanTreeCSV(failingWriter{}, sampleSpans()), "disk full")
+}
@@ -0,0 +1,162 @@
+package printer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// diffNode pairs the golden and current span sharing a canonical key. Either
+// side may be nil when the span exists in only one set.
+type diffNode struct {
+	key     string
+	golden  *tracetest.SpanStub
+	current *tracetest.SpanStub
+}
+
+// RenderDiff compares a golden set of spans against a current one and returns
+// the merged tree, marking spans only in current with "+" and spans only in
+// golden with "-".
+//
+// Spans are matched by canonical key: the path of span names from the root,
+// with a "#n" suffix distinguishing repeated sibling names in start-time
+// order. IDs and timestamps are ignored, so two captures of the same
+// operation line up. For matched spans, attribute differences are listed
+// beneath the span: added keys with "+", removed keys with "-", and changed
+// values as "~ key: old → new".
+func RenderDiff(golden, current []tracetest.SpanStub, opts ...Option) string {
+	cfg := newConfig(opts...)
+	goldenTree, currentTree := newSpanTree(cfg, golden), newSpanTree(cfg, current)
+
+	var lines []string
+	var walk func(goldenKids, currentKids []tracetest.SpanStub, prefix string, depth int)
+	walk = func(goldenKids, currentKids []tracetest.SpanStub, prefix string, depth int) {
+		indent := strings.Repeat(cfg.Indent, depth)
+		for _, node := range pairByKey(goldenKids, currentKids, prefix) {
+			var gKids, cKids []tracetest.SpanStub
+			switch {
+			case node.golden == nil:
+				lines = append(lines, cfg.styles.added.Render("+ "+indent+node.current.Name))
+				cKids = currentTree.childrenOf(*node.current)
+			case node.current == nil:
+				lines = append(lines, cfg.styles.removed.Render("- "+indent+node.golden.Name))
+				gKids = goldenTree.childrenOf(*node.golden)
+			default:
+				lines = append(lines, cfg.styles.value.Render("  "+indent+node.current.Name))
+				for _, line := range attributeDiff(cfg, node.golden.Attributes, node.current.Attributes) {
+					lines = append(lines, "  "+indent+childIndent+line)
+				}
+				gKids = goldenTree.childrenOf(*node.golden)
+				cKids = currentTree.childrenOf(*node.current)
+			}
+			walk(gKids, cKids, node.key, depth+1)
+		}
+	}
+	walk(goldenTree.roots, currentTree.roots, "", 0)
+
+	return strings.Join(lines, "\n")
+}
+
+// canonicalKeys returns the canonical key of each of siblings, in order,
+// beneath the parent key prefix.
+func canonicalKeys(siblings []tracetest.SpanStub, prefix string) []string {
+	seen := make(map[string]int, len(siblings))
+	keys := make([]string, len(siblings))
+	for i, s := range siblings {
+		seen[s.Name]++
+		key := s.Name
+		if n := seen[s.Name]; n > 1 {
+			key = fmt.Sprintf("%s#%d", s.Name, n)
+		}
+		if prefix != "" {
+			key = prefix + "/" + key
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// keyedSpans returns every span in tree keyed by canonical key, along with
+// the keys in depth-first order.
+func keyedSpans(tree *spanTree) ([]string, map[string]tracetest.SpanStub) {
+	var order []string
+	byKey := make(map[string]tracetest.SpanStub)
+
+	var walk func(siblings []tracetest.SpanStub, prefix string)
+	walk = func(siblings []tracetest.SpanStub, prefix string) {
+		for i, key := range canonicalKeys(siblings, prefix) {
+			order = append(order, key)
+			byKey[key] = siblings[i]
+			walk(tree.childrenOf(siblings[i]), key)
+		}
+	}
+	walk(tree.roots, "")
+
+	return order, byKey
+}
+
+// pairByKey matches golden and current siblings by canonical key. Golden
+// order is kept, with current-only spans following in their own order.
+func pairByKey(golden, current []tracetest.SpanStub, prefix string) []diffNode {
+	var nodes []diffNode
+	index := make(map[string]int)
+
+	for i, key := range canonicalKeys(golden, prefix) {
+		index[key] = len(nodes)
+		nodes = append(nodes, diffNode{key: key, golden: &golden[i]})
+	}
+	for i, key := range canonicalKeys(current, prefix) {
+		if j, ok := index[key]; ok {
+			nodes[j].current = &current[i]
+			continue
+		}
+		nodes = append(nodes, diffNode{key: key, current: &current[i]})
+	}
+	return nodes
+}
+
+// attributeDiff returns styled lines describing how the current attributes
+// differ from the golden ones, sorted by key. Values are formatted as in
+// PrintSpanTree, so a changed redacted attribute is listed without its
+// values.
+func attributeDiff(cfg *Config, golden, current []attribute.KeyValue) []string {
+	before := make(map[attribute.Key]attribute.Value, len(golden))
+	for _, attr := range golden {
+		before[attr.Key] = attr.Value
+	}
+	after := make(map[attribute.Key]attribute.Value, len(current))
+	for _, attr := range current {
+		after[attr.Key] = attr.Value
+	}
+
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, string(k))
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, string(k))
+		}
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, k := range keys {
+		old, hadOld := before[attribute.Key(k)]
+		val, hasNew := after[attribute.Key(k)]
+		switch {
+		case !hadOld:
+			lines = append(lines, cfg.styles.added.Render(fmt.Sprintf("+ %s = %s", k, formatAttributeValue(cfg, attribute.KeyValue{Key: attribute.Key(k), Value: val}))))
+		case !hasNew:
+			lines = append(lines, cfg.styles.removed.Render(fmt.Sprintf("- %s = %s", k, formatAttributeValue(cfg, attribute.KeyValue{Key: attribute.Key(k), Value: old}))))
+		case old.Type() != val.Type() || old.Emit() != val.Emit():
+			lines = append(lines, cfg.styles.changed.Render(fmt.Sprintf("~ %s: %s → %s", k,
+				formatAttributeValue(cfg, attribute.KeyValue{Key: attribute.Key(k), Value: old}),
+				formatAttributeValue(cfg, attribute.KeyValue{Key: attribute.Key(k), Value: val}))))
+		}
+	}
+	return lines
+}
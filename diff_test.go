@@ -0,0 +1,56 @@
+package printer_test
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderDiff(t *testing.T) {
+	golden := sampleSpans()
+
+	current := sampleSpans()
+	current[1].Attributes = append(current[1].Attributes, attribute.String("retry", "true"))
+	current[2].Attributes = []attribute.KeyValue{
+		attribute.String("component", "child-2-renamed"),
+	}
+	current = current[:3] // drop child-span-3
+	current = append(current, tracetest.SpanStub{
+		Name:        "child-span-4",
+		SpanContext: golden[3].SpanContext,
+		Parent:      golden[0].SpanContext,
+		StartTime:   golden[3].StartTime,
+		EndTime:     golden[3].EndTime,
+	})
+
+	output := printer.RenderDiff(golden, current)
+
+	must.StrContains(t, output, "  root-span")
+	must.StrContains(t, output, "+ retry = true")
+	must.StrContains(t, output, "~ component: child-2 → child-2-renamed")
+	must.StrContains(t, output, "- error_code = something_wrong")
+	must.StrContains(t, output, "-     child-span-3")
+	must.StrContains(t, output, "+   child-span-4")
+	must.StrNotContains(t, output, "+ component")
+}
+
+func TestRenderDiff_Redaction(t *testing.T) {
+	golden := sampleSpans()
+	golden[1].Attributes = append(golden[1].Attributes, attribute.String("password", "hunter2"))
+
+	current := sampleSpans()
+	current[1].Attributes = append(current[1].Attributes, attribute.String("password", "swordfish"))
+	current[2].Attributes = append(current[2].Attributes, attribute.String("api_key", "sk-123"))
+
+	output := printer.RenderDiff(golden, current)
+
+	must.StrContains(t, output, "~ password: «redacted» → «redacted»")
+	must.StrContains(t, output, "+ api_key = «redacted»")
+	must.StrNotContains(t, output, "hunter2")
+	must.StrNotContains(t, output, "swordfish")
+	must.StrNotContains(t, output, "sk-123")
+}
@@ -0,0 +1,74 @@
+package printer
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// digestTraceIDLength is how many hex digits of the trace ID RenderDigest
+// shows.
+const digestTraceIDLength = 4
+
+// RenderDigest summarizes spans in a single sentence suitable for a commit
+// message or chat, such as:
+//
+//	Trace ab12 (7 spans, 3 services) completed in 220ms with 1 error in 'db.query'.
+//
+// The trace ID is that of the earliest root and the duration is the wall-clock
+// time across all spans. When spans failed, the earliest failed span is
+// named; otherwise the slowest non-root span is, or the root itself when it
+// has no children. It returns "" when there are no spans.
+func RenderDigest(spans []tracetest.SpanStub) string {
+	if len(spans) == 0 {
+		return ""
+	}
+
+	cfg := newConfig()
+	tree := newSpanTree(cfg, spans)
+
+	first := spans[0]
+	if len(tree.roots) > 0 {
+		first = tree.roots[0]
+	}
+
+	services := make(map[string]bool)
+	var failed, slowest *tracetest.SpanStub
+	var slowestDuration time.Duration
+	errors := 0
+	for i, s := range spans {
+		services[serviceName(s)] = true
+		if isErrorSpan(cfg, s) {
+			errors++
+			if failed == nil || s.StartTime.Before(failed.StartTime) {
+				failed = &spans[i]
+			}
+		}
+		if s.Parent.SpanID().IsValid() {
+			d, _ := spanDuration(s)
+			if slowest == nil || d > slowestDuration {
+				slowest, slowestDuration = &spans[i], d
+			}
+		}
+	}
+	if slowest == nil {
+		slowest = &first
+	}
+
+	sentence := fmt.Sprintf("Trace %s (%s, %s) completed in %s",
+		first.SpanContext.TraceID().String()[:digestTraceIDLength],
+		plural(len(spans), "span", "spans"),
+		plural(len(services), "service", "services"),
+		formatDuration(cfg, wallClock(spans)))
+
+	switch {
+	case errors == 1:
+		sentence += fmt.Sprintf(" with 1 error in '%s'", failed.Name)
+	case errors > 1:
+		sentence += fmt.Sprintf(" with %d errors, first in '%s'", errors, failed.Name)
+	default:
+		sentence += fmt.Sprintf("; slowest span '%s' took %s", slowest.Name, formatSpanDuration(cfg, *slowest))
+	}
+	return sentence + "."
+}
@@ -0,0 +1,36 @@
+package printer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderDigest(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("GET /checkout", 1, 0, 0, 220*ms),
+		newSpan("cart.load", 2, 1, 10*ms, 40*ms),
+		newSpan("db.query", 3, 2, 15*ms, 35*ms),
+		newSpan("payment.charge", 4, 1, 50*ms, 200*ms),
+	}
+	for i, service := range []string{"frontend", "cart", "cart", "payments"} {
+		spans[i].Resource = resource.NewSchemaless(semconv.ServiceName(service))
+	}
+	spans[2].Status = sdktrace.Status{Code: codes.Error, Description: "timeout"}
+
+	must.Eq(t, "Trace aabb (4 spans, 3 services) completed in 220ms with 1 error in 'db.query'.", printer.RenderDigest(spans))
+
+	spans[2].Status = sdktrace.Status{}
+	must.Eq(t, "Trace aabb (4 spans, 3 services) completed in 220ms; slowest span 'payment.charge' took 150ms.", printer.RenderDigest(spans))
+
+	must.Eq(t, "", printer.RenderDigest(nil))
+}
@@ -0,0 +1,56 @@
+package printer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// PrintSpanTreeDOT writes spans to w as a Graphviz DOT digraph, ready to be
+// piped through "dot -Tpng". Each span is a node, named by its full span ID,
+// whose label is a table of the span's name, duration, and status; edges
+// point from parents to their children. Error spans are outlined in red.
+// Spans whose parent isn't among spans appear as additional roots.
+func PrintSpanTreeDOT(w io.Writer, spans []tracetest.SpanStub) error {
+	cfg := newConfig()
+	tree := newSpanTree(cfg, spans)
+	esc := html.EscapeString
+
+	var b strings.Builder
+	b.WriteString("digraph spans {\n")
+	b.WriteString("  node [shape=plaintext];\n")
+
+	var walk func(span tracetest.SpanStub)
+	walk = func(span tracetest.SpanStub) {
+		id := span.SpanContext.SpanID().String()
+
+		color := "black"
+		if isErrorSpan(cfg, span) {
+			color = "red"
+		}
+		fmt.Fprintf(&b, "  %q [label=<<TABLE BORDER=\"1\" CELLBORDER=\"0\" CELLSPACING=\"0\" COLOR=%q>"+
+			"<TR><TD><B>%s</B></TD></TR><TR><TD>%s</TD></TR><TR><TD>%s</TD></TR></TABLE>>];\n",
+			id, color,
+			esc(span.Name),
+			esc(formatSpanDuration(cfg, span)),
+			esc(span.Status.Code.String()),
+		)
+
+		for _, child := range tree.childrenOf(span) {
+			fmt.Fprintf(&b, "  %q -> %q;\n", id, child.SpanContext.SpanID().String())
+			walk(child)
+		}
+	}
+
+	for _, root := range tree.roots {
+		walk(root)
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
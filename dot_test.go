@@ -0,0 +1,36 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTreeDOT(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("GET /a&b", 1, 0, 0, 100*ms),
+		newSpan("db.query", 2, 1, 10*ms, 60*ms),
+		newSpan("second-root", 3, 0, 0, 5*ms),
+		newSpan("orphan", 5, 4, 20*ms, 30*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeDOT(&buf, spans))
+	output := buf.String()
+
+	must.True(t, strings.HasPrefix(output, "digraph spans {\n"))
+	must.True(t, strings.HasSuffix(output, "}\n"))
+	must.Eq(t, 1, strings.Count(output, " -> "))
+	must.StrContains(t, output, `"`+spans[0].SpanContext.SpanID().String()+`" -> "`+spans[1].SpanContext.SpanID().String()+`";`)
+	must.StrContains(t, output, "<B>GET /a&amp;b</B>")
+	must.StrContains(t, output, "<TD>50.0ms</TD>")
+	must.Eq(t, 4, strings.Count(output, "[label=<"))
+	must.StrContains(t, output, "<B>orphan</B>")
+}
@@ -0,0 +1,105 @@
+package printer
+
+import (
+	"path"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// filterSpans applies the span filters enabled in cfg, returning the spans
+// that should be rendered. Filters that match a span also keep its ancestors
+// so the surrounding tree still makes sense.
+func filterSpans(cfg *Config, spans []tracetest.SpanStub) []tracetest.SpanStub {
+	if cfg.ActiveOnly {
+		spans = retainWithAncestors(spans, func(s tracetest.SpanStub) bool {
+			return s.EndTime.IsZero()
+		})
+	}
+	if cfg.NameFilter != "" {
+		spans = retainWithAncestors(spans, func(s tracetest.SpanStub) bool {
+			matched, err := path.Match(cfg.NameFilter, s.Name)
+			return err == nil && matched
+		})
+	}
+	if cfg.MinDuration > 0 {
+		kept := retainWithAncestors(spans, func(s tracetest.SpanStub) bool {
+			d, ok := spanDuration(s)
+			return !ok || d >= cfg.MinDuration
+		})
+		cfg.fastHidden = countHidden(spans, kept)
+		spans = kept
+	}
+	return spans
+}
+
+// countHidden returns, keyed by SpanID, how many of the spans left out of
+// kept have each kept span as their nearest kept ancestor.
+func countHidden(spans, kept []tracetest.SpanStub) map[string]int {
+	byID := make(map[string]tracetest.SpanStub, len(spans))
+	for _, s := range spans {
+		byID[s.SpanContext.SpanID().String()] = s
+	}
+	shown := make(map[string]bool, len(kept))
+	for _, s := range kept {
+		shown[s.SpanContext.SpanID().String()] = true
+	}
+
+	counts := make(map[string]int)
+	for _, s := range spans {
+		if shown[s.SpanContext.SpanID().String()] {
+			continue
+		}
+		// Bound the walk by the number of spans in case parents form a
+		// cycle.
+		current := s
+		for range spans {
+			parent, ok := byID[current.Parent.SpanID().String()]
+			if !current.Parent.SpanID().IsValid() || !ok {
+				break
+			}
+			if id := parent.SpanContext.SpanID().String(); shown[id] {
+				counts[id]++
+				break
+			}
+			current = parent
+		}
+	}
+	return counts
+}
+
+// retainWithAncestors returns the spans for which keep reports true, plus
+// every ancestor of those spans present in spans. Input order is preserved.
+func retainWithAncestors(spans []tracetest.SpanStub, keep func(tracetest.SpanStub) bool) []tracetest.SpanStub {
+	byID := make(map[string]tracetest.SpanStub, len(spans))
+	for _, s := range spans {
+		byID[s.SpanContext.SpanID().String()] = s
+	}
+
+	retained := make(map[string]bool)
+	for _, s := range spans {
+		if !keep(s) {
+			continue
+		}
+		// Walk up the parent chain, stopping at spans already retained
+		// (or missing from the input).
+		for id := s.SpanContext.SpanID().String(); !retained[id]; {
+			retained[id] = true
+			current := byID[id]
+			if !current.Parent.SpanID().IsValid() {
+				break
+			}
+			id = current.Parent.SpanID().String()
+			if _, ok := byID[id]; !ok {
+				break
+			}
+		}
+	}
+
+	var out []tracetest.SpanStub
+	for _, s := range spans {
+		if retained[s.SpanContext.SpanID().String()] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
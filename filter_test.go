@@ -0,0 +1,92 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTree_ActiveOnly(t *testing.T) {
+	ms := time.Millisecond
+
+	inFlight := newSpan("db.query", 4, 3, 30*ms, 0)
+	inFlight.EndTime = time.Time{}
+
+	spans := []tracetest.SpanStub{
+		newSpan("handler", 1, 0, 0, 100*ms),
+		newSpan("auth", 2, 1, 10*ms, 20*ms),
+		newSpan("load", 3, 1, 20*ms, 0),
+		inFlight,
+		newSpan("cache.get", 5, 3, 21*ms, 25*ms),
+	}
+	spans[2].EndTime = time.Time{}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithActiveOnly(true)))
+	output := buf.String()
+
+	// handler has ended but is kept as the ancestor of in-flight spans.
+	must.StrContains(t, output, "handler")
+	must.StrContains(t, output, "load")
+	must.StrContains(t, output, "db.query")
+	must.StrNotContains(t, output, "auth")
+	must.StrNotContains(t, output, "cache.get")
+}
+
+func TestPrintSpanTree_MinDuration(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("handler", 1, 0, 0, 500*ms),
+		newSpan("auth", 2, 1, 0, 5*ms),
+		newSpan("auth.cache", 3, 2, 1*ms, 2*ms),
+		newSpan("middleware", 4, 1, 10*ms, 20*ms),
+		newSpan("render", 5, 4, 10*ms, 200*ms),
+		newSpan("db.query", 6, 1, 200*ms, 450*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithMinDuration(100*ms)))
+	output := buf.String()
+
+	for _, name := range []string{"handler", "middleware", "render", "db.query"} {
+		must.StrContains(t, output, "Span Name:  "+name+" ")
+	}
+	for _, name := range []string{"auth", "auth.cache"} {
+		must.StrNotContains(t, output, "Span Name:  "+name+" ")
+	}
+	must.StrContains(t, output, "(2 fast spans hidden)")
+	must.Eq(t, 1, strings.Count(output, "hidden)"))
+}
+
+func TestPrintSpanTree_NameFilter(t *testing.T) {
+	spans := sampleSpans()
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithNameFilter("child-*")))
+	output := buf.String()
+
+	must.StrContains(t, output, "Span Name:  root-span")
+	for _, s := range spans[1:] {
+		must.StrContains(t, output, "Span Name:  "+s.Name)
+	}
+
+	// child-span-2 stays as the parent of the match, but its sibling goes
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithNameFilter("child-span-3")))
+	output = buf.String()
+
+	must.StrContains(t, output, "Span Name:  root-span")
+	must.StrContains(t, output, "Span Name:  child-span-2")
+	must.StrContains(t, output, "Span Name:  child-span-3")
+	must.StrNotContains(t, output, "child-span-1")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithNameFilter("db.*")))
+	must.Eq(t, "", buf.String())
+}
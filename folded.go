@@ -0,0 +1,45 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// foldedSanitizer replaces the frame separator and line breaks in span names
+// so each stack stays on one line.
+var foldedSanitizer = strings.NewReplacer(";", "_", "\n", " ", "\r", " ")
+
+// WriteFolded writes spans to w in the "folded stack" format consumed by
+// flamegraph.pl: one line per span, made of the semicolon-separated names on
+// the path from its root followed by its self time in microseconds. Spans
+// with no self time are omitted, since flame graphs derive a parent's width
+// from its own line plus those of its descendants.
+func WriteFolded(w io.Writer, spans []tracetest.SpanStub) error {
+	tree := newSpanTree(newConfig(), spans)
+
+	var b strings.Builder
+	var walk func(span tracetest.SpanStub, path string)
+	walk = func(span tracetest.SpanStub, path string) {
+		name := foldedSanitizer.Replace(span.Name)
+		if path != "" {
+			name = path + ";" + name
+		}
+
+		children := tree.childrenOf(span)
+		if self := selfTime(span, children).Microseconds(); self > 0 {
+			fmt.Fprintf(&b, "%s %d\n", name, self)
+		}
+		for _, child := range children {
+			walk(child, name)
+		}
+	}
+	for _, root := range tree.roots {
+		walk(root, "")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,36 @@
+package printer_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestWriteFolded(t *testing.T) {
+	spans := sampleSpans()
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.WriteFolded(&buf, spans))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	selfTimes := make(map[string]int64, len(lines))
+	for _, line := range lines {
+		i := strings.LastIndexByte(line, ' ')
+		must.Positive(t, i)
+		micros, err := strconv.ParseInt(line[i+1:], 10, 64)
+		must.NoError(t, err)
+		selfTimes[line[:i]] = micros
+	}
+
+	leaf, ok := selfTimes["root-span;child-span-2;child-span-3"]
+	must.True(t, ok, must.Sprintf("missing leaf stack in:\n%s", buf.String()))
+	must.Between(t, int64(299_000), leaf, int64(301_000))
+
+	must.MapContainsKey(t, selfTimes, "root-span;child-span-1")
+	must.Between(t, int64(499_000), selfTimes["root-span"], int64(501_000))
+}
@@ -0,0 +1,58 @@
+package printer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// noneGroup names the group of spans lacking the grouping attribute.
+const noneGroup = "(none)"
+
+// RenderGroupedByAttribute buckets spans by the value of the attribute key,
+// such as "tenant" or "http.route", and lists each group under a header
+// naming the value and its span count. Groups are sorted by value, with spans
+// lacking the attribute collected last under "(none)". Within a group, spans
+// keep their tree order.
+func RenderGroupedByAttribute(spans []tracetest.SpanStub, key string, opts ...Option) string {
+	cfg := newConfig(opts...)
+	tree := newSpanTree(cfg, filterSpans(cfg, spans))
+
+	groups := make(map[string][]tracetest.SpanStub)
+	for _, s := range tree.descendants(tree.roots) {
+		value := noneGroup
+		for _, attr := range s.Attributes {
+			if attr.Key == attribute.Key(key) {
+				value = formatAttributeValue(cfg, attr)
+				break
+			}
+		}
+		groups[value] = append(groups[value], s)
+	}
+
+	values := make([]string, 0, len(groups))
+	for value := range groups {
+		if value != noneGroup {
+			values = append(values, value)
+		}
+	}
+	slices.Sort(values)
+	if _, ok := groups[noneGroup]; ok {
+		values = append(values, noneGroup)
+	}
+
+	var lines []string
+	for _, value := range values {
+		members := groups[value]
+		header := fmt.Sprintf("%s = %s (%s)", key, value, plural(len(members), "span", "spans"))
+		lines = append(lines, cfg.styles.label.Render(header))
+		for _, s := range members {
+			entry := fmt.Sprintf("• %s (%s)", s.Name, formatSpanDuration(cfg, s))
+			lines = append(lines, childIndent+cfg.styles.value.Render(entry))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
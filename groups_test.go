@@ -0,0 +1,25 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderGroupedByAttribute(t *testing.T) {
+	spans := sampleSpans()
+	spans[3].Attributes = []attribute.KeyValue{attribute.String("component", "child-2")}
+	spans = append(spans, newSpan("untagged", 9, 0, 0, 1))
+
+	output := printer.RenderGroupedByAttribute(spans, "component")
+
+	must.Eq(t, 4, strings.Count(output, "component = "))
+	must.StrContains(t, output, "component = child-1 (1 span)\n  • child-span-1")
+	must.StrContains(t, output, "component = child-2 (2 spans)\n  • child-span-2")
+	must.StrContains(t, output, "component = root (1 span)\n  • root-span")
+	must.StrHasSuffix(t, "component = (none) (1 span)\n  • untagged (1ns)", output)
+}
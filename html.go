@@ -0,0 +1,101 @@
+package printer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// PrintSpanTreeHTML writes spans to w as a self-contained HTML fragment that
+// mirrors the tree printed by PrintSpanTree. Each span is a <details>
+// element, initially open, whose <summary> gives its name and duration, so
+// subtrees can be collapsed in the browser; error spans have the
+// "span-error" class. Attributes, events, and links are wrapped in <details>
+// elements of their own so large sets stay collapsed until expanded. Names
+// and values are HTML-escaped.
+//
+// It returns the error, if any, from writing to w.
+func PrintSpanTreeHTML(w io.Writer, spans []tracetest.SpanStub, opts ...Option) error {
+	return NewPrinter(opts...).PrintHTML(w, spans)
+}
+
+// PrintHTML writes spans to w as PrintSpanTreeHTML does, using p's
+// configuration.
+func (p *Printer) PrintHTML(w io.Writer, spans []tracetest.SpanStub) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	cfg := writerConfig(p.cfg, w)
+	spans = filterSpans(cfg, spans)
+	tree := newSpanTree(cfg, spans)
+
+	var b strings.Builder
+	b.WriteString("<div class=\"span-tree\">\n")
+	for _, root := range tree.roots {
+		writeSpanHTML(&b, cfg, tree, root)
+	}
+	b.WriteString("</div>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeSpanHTML appends the HTML for span and, recursively, its children.
+func writeSpanHTML(b *strings.Builder, cfg *Config, tree *spanTree, span tracetest.SpanStub) {
+	esc := html.EscapeString
+
+	class := "span"
+	if isErrorSpan(cfg, span) {
+		class += " span-error"
+	}
+	fmt.Fprintf(b, "<details class=\"%s\" open>\n", class)
+	fmt.Fprintf(b, "<summary><span class=\"span-name\">%s</span> (%s)</summary>\n",
+		esc(span.Name),
+		esc(formatSpanDuration(cfg, span)),
+	)
+	fmt.Fprintf(b, "<div class=\"span-meta\">TraceID: %s · SpanID: %s</div>\n",
+		esc(span.SpanContext.TraceID().String()),
+		esc(span.SpanContext.SpanID().String()),
+	)
+
+	if len(span.Attributes) > 0 {
+		fmt.Fprintf(b, "<details><summary>Attributes (%d)</summary>\n<dl>\n", len(span.Attributes))
+		for _, attr := range sortedAttributes(cfg, span.Attributes) {
+			fmt.Fprintf(b, "<dt>%s</dt><dd>%s</dd>\n", esc(string(attr.Key)), esc(formatAttributeValue(cfg, attr)))
+		}
+		b.WriteString("</dl>\n</details>\n")
+	}
+
+	if len(span.Events) > 0 {
+		fmt.Fprintf(b, "<details><summary>Events (%d)</summary>\n<ul>\n", len(span.Events))
+		for _, event := range span.Events {
+			fmt.Fprintf(b, "<li>%s at %s</li>\n", esc(event.Name), esc(formatTime(cfg, event.Time)))
+		}
+		b.WriteString("</ul>\n</details>\n")
+	}
+
+	if len(span.Links) > 0 {
+		fmt.Fprintf(b, "<details><summary>Links (%d)</summary>\n<ul>\n", len(span.Links))
+		for _, link := range span.Links {
+			fmt.Fprintf(b, "<li>TraceID: %s · SpanID: %s</li>\n",
+				esc(link.SpanContext.TraceID().String()),
+				esc(link.SpanContext.SpanID().String()),
+			)
+		}
+		b.WriteString("</ul>\n</details>\n")
+	}
+
+	if children := tree.childrenOf(span); len(children) > 0 {
+		b.WriteString("<div class=\"span-children\">\n")
+		for _, child := range children {
+			writeSpanHTML(b, cfg, tree, child)
+		}
+		b.WriteString("</div>\n")
+	}
+
+	b.WriteString("</details>\n")
+}
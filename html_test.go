@@ -0,0 +1,78 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTreeHTML(t *testing.T) {
+	spans := sampleSpans()
+	spans[0].Attributes = append(spans[0].Attributes,
+		attribute.String("http.route", "/users/<id>"),
+		attribute.Int("http.status_code", 200),
+	)
+	spans[0].Events = []sdktrace.Event{{Name: "request.start", Time: spans[0].StartTime}}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeHTML(&buf, spans))
+	output := buf.String()
+
+	must.StrContains(t, output, "<details><summary>Attributes (3)</summary>\n<dl>\n<dt>component</dt>")
+	must.StrContains(t, output, "<details><summary>Events (1)</summary>")
+	must.StrContains(t, output, "/users/&lt;id&gt;")
+	must.Eq(t, strings.Count(output, "<details"), strings.Count(output, "</details>"))
+	must.Eq(t, 4, strings.Count(output, "<details class=\"span"))
+
+	for _, name := range []string{"root-span", "child-span-1", "child-span-2", "child-span-3"} {
+		must.StrContains(t, output, name)
+	}
+}
+
+func TestPrintSpanTreeHTML_ErrorsAndEscaping(t *testing.T) {
+	spans := sampleSpans()
+	spans[0].Name = "<b>root</b>"
+	spans[1].Attributes = []attribute.KeyValue{attribute.String("payload", "<script>alert(1)</script>")}
+	spans[3].Status = sdktrace.Status{Code: codes.Error, Description: "timeout"}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeHTML(&buf, spans))
+	output := buf.String()
+
+	must.StrNotContains(t, output, "<script>")
+	must.StrNotContains(t, output, "<b>")
+	must.StrContains(t, output, "&lt;script&gt;alert(1)&lt;/script&gt;")
+	must.StrContains(t, output, "<summary><span class=\"span-name\">&lt;b&gt;root&lt;/b&gt;</span>")
+
+	// child-span-2 fails through its error_code attribute, child-span-3
+	// through its status
+	must.Eq(t, 2, strings.Count(output, "span-error"))
+	must.StrContains(t, output, "<details class=\"span span-error\" open>\n<summary><span class=\"span-name\">child-span-3</span>")
+}
+
+func TestPrintSpanTreeHTML_AttributeOrder(t *testing.T) {
+	spans := sampleSpans()[:1]
+	spans[0].Attributes = []attribute.KeyValue{
+		attribute.String("z", "last"),
+		attribute.String("a", "first"),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeHTML(&buf, spans))
+	must.StrContains(t, buf.String(), "<dt>a</dt><dd>first</dd>\n<dt>z</dt><dd>last</dd>")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTreeHTML(&buf, spans, printer.WithAttributeSort(false)))
+	must.StrContains(t, buf.String(), "<dt>z</dt><dd>last</dd>\n<dt>a</dt><dd>first</dd>")
+}
+
+func TestPrintSpanTreeHTML_WriteError(t *testing.T) {
+	must.ErrorContains(t, printer.PrintSpanTreeHTML(failingWriter{}, sampleSpans()), "disk full")
+}
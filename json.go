@@ -0,0 +1,93 @@
+package printer
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// jsonSpan is the JSON representation of a span and its descendants.
+type jsonSpan struct {
+	Name          string         `json:"name"`
+	TraceID       string         `json:"trace_id"`
+	SpanID        string         `json:"span_id"`
+	ParentID      string         `json:"parent_id,omitempty"`
+	StartTime     string         `json:"start_time"`
+	EndTime       string         `json:"end_time"`
+	DurationNanos int64          `json:"duration_ns"`
+	Attributes    map[string]any `json:"attributes"`
+	Status        jsonStatus     `json:"status"`
+	Children      []jsonSpan     `json:"children"`
+}
+
+// jsonStatus is the JSON representation of a span's status.
+type jsonStatus struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+// PrintSpanTreeJSON writes spans to w as a JSON array of root spans, each
+// nesting its children in the same hierarchy PrintSpanTree draws. Spans whose
+// parent isn't among spans are included as roots.
+//
+// Each node has the span's name, trace, span, and parent IDs, start and end
+// times in RFC 3339 format with nanoseconds, duration in nanoseconds (zero
+// for spans that haven't ended or end before they start), attributes as an
+// object, status, and children. Attribute values keep their
+// JSON types, except that redacted attributes (see WithRedactedKeys) hold the
+// redaction placeholder.
+func PrintSpanTreeJSON(w io.Writer, spans []tracetest.SpanStub, opts ...Option) error {
+	return NewPrinter(opts...).PrintJSON(w, spans)
+}
+
+// PrintJSON writes spans to w as PrintSpanTreeJSON does, using p's
+// configuration.
+func (p *Printer) PrintJSON(w io.Writer, spans []tracetest.SpanStub) error {
+	cfg := writerConfig(p.cfg, w)
+	tree := newSpanTree(cfg, filterSpans(cfg, spans))
+
+	var build func(span tracetest.SpanStub) jsonSpan
+	build = func(span tracetest.SpanStub) jsonSpan {
+		node := jsonSpan{
+			Name:       span.Name,
+			TraceID:    span.SpanContext.TraceID().String(),
+			SpanID:     span.SpanContext.SpanID().String(),
+			StartTime:  span.StartTime.Format(time.RFC3339Nano),
+			EndTime:    span.EndTime.Format(time.RFC3339Nano),
+			Attributes: make(map[string]any, len(span.Attributes)),
+			Status: jsonStatus{
+				Code:        span.Status.Code.String(),
+				Description: span.Status.Description,
+			},
+			Children: []jsonSpan{},
+		}
+		if d, ok := spanDuration(span); ok {
+			node.DurationNanos = d.Nanoseconds()
+		}
+		if span.Parent.SpanID().IsValid() {
+			node.ParentID = span.Parent.SpanID().String()
+		}
+		for _, attr := range span.Attributes {
+			if isRedacted(cfg.RedactedKeys, string(attr.Key)) {
+				node.Attributes[string(attr.Key)] = redactedValue
+				continue
+			}
+			node.Attributes[string(attr.Key)] = attr.Value.AsInterface()
+		}
+		for _, child := range tree.childrenOf(span) {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	doc := make([]jsonSpan, 0, len(tree.roots))
+	for _, root := range tree.roots {
+		doc = append(doc, build(root))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
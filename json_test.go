@@ -0,0 +1,100 @@
+package printer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+type jsonNode struct {
+	Name       string         `json:"name"`
+	SpanID     string         `json:"span_id"`
+	ParentID   string         `json:"parent_id"`
+	StartTime  string         `json:"start_time"`
+	Duration   int64          `json:"duration_ns"`
+	Attributes map[string]any `json:"attributes"`
+	Status     struct {
+		Code string `json:"code"`
+	} `json:"status"`
+	Children []jsonNode `json:"children"`
+}
+
+func TestPrintSpanTreeJSON(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("child", 2, 1, 10*ms, 60*ms),
+		newSpan("orphan", 4, 3, 20*ms, 30*ms),
+	}
+	spans[1].Attributes = []attribute.KeyValue{attribute.Int("http.status_code", 200)}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeJSON(&buf, spans))
+
+	var roots []jsonNode
+	must.NoError(t, json.Unmarshal(buf.Bytes(), &roots))
+	must.SliceLen(t, 2, roots)
+
+	root := roots[0]
+	must.Eq(t, "root", root.Name)
+	must.Eq(t, "", root.ParentID)
+	must.Eq(t, "2024-01-02T15:04:05Z", root.StartTime)
+	must.Eq(t, int64(100*ms), root.Duration)
+	must.Eq(t, "Unset", root.Status.Code)
+	must.SliceLen(t, 1, root.Children)
+
+	child := root.Children[0]
+	must.Eq(t, "child", child.Name)
+	must.Eq(t, root.SpanID, child.ParentID)
+	must.Eq[any](t, float64(200), child.Attributes["http.status_code"])
+	must.SliceEmpty(t, child.Children)
+
+	must.Eq(t, "orphan", roots[1].Name)
+	must.Eq(t, spans[2].Parent.SpanID().String(), roots[1].ParentID)
+}
+
+func TestPrintSpanTreeJSON_Redaction(t *testing.T) {
+	span := newSpan("login", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.String("password", "hunter2"),
+		attribute.Int("user.id", 42),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeJSON(&buf, []tracetest.SpanStub{span}))
+	must.StrNotContains(t, buf.String(), "hunter2")
+
+	var roots []jsonNode
+	must.NoError(t, json.Unmarshal(buf.Bytes(), &roots))
+	must.Eq[any](t, "«redacted»", roots[0].Attributes["password"])
+	must.Eq[any](t, float64(42), roots[0].Attributes["user.id"])
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTreeJSON(&buf, []tracetest.SpanStub{span}, printer.WithRedactedKeys("user.id")))
+	must.StrContains(t, buf.String(), "hunter2")
+	must.StrNotContains(t, buf.String(), "42")
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestPrintSpanTreeJSON_WriteError(t *testing.T) {
+	err := printer.PrintSpanTreeJSON(failingWriter{}, sampleSpans())
+	must.ErrorContains(t, err, "disk full")
+
+	span := newSpan("ratio", 1, 0, 0, time.Millisecond)
+	span.Attributes = []attribute.KeyValue{attribute.Float64("cache.hit_ratio", math.NaN())}
+	must.Error(t, printer.PrintSpanTreeJSON(io.Discard, []tracetest.SpanStub{span}))
+}
@@ -0,0 +1,73 @@
+package printer
+
+import (
+	"encoding/base32"
+	"hash/fnv"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// IDKind identifies which kind of ID is being rendered.
+type IDKind int
+
+const (
+	// TraceIDKind is a trace ID.
+	TraceIDKind IDKind = iota
+
+	// SpanIDKind is a span ID, including parent span IDs.
+	SpanIDKind
+)
+
+// String returns the name of the ID kind.
+func (k IDKind) String() string {
+	switch k {
+	case TraceIDKind:
+		return "trace"
+	case SpanIDKind:
+		return "span"
+	default:
+		return "unknown"
+	}
+}
+
+// linkID wraps id in an OSC 8 terminal hyperlink to the URL built by
+// cfg.IDLinks. The id is returned unlinked when no builder is configured or
+// it returns an empty URL. Either way it is shortened by displayID, while
+// the URL is built from the full id.
+func linkID(cfg *Config, kind IDKind, id string) string {
+	text := displayID(cfg, id)
+	if cfg.IDLinks == nil {
+		return text
+	}
+	url := cfg.IDLinks(kind, id)
+	if url == "" {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// shortIDLength is the number of trailing hex characters kept by ShortIDs.
+const shortIDLength = 8
+
+// displayID returns id as it should be shown: with cfg.ShortIDs, only its
+// last shortIDLength characters after a leading "…".
+func displayID(cfg *Config, id string) string {
+	if !cfg.ShortIDs || len(id) <= shortIDLength {
+		return id
+	}
+	return "…" + id[len(id)-shortIDLength:]
+}
+
+// shortLabelEncoding renders short labels in lowercase base32 without
+// padding.
+var shortLabelEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// ShortLabel returns a short, stable label for id, such as "a7k2", for
+// cross-referencing spans without their full hex IDs. The label is derived
+// from a hash of every byte of the ID, so it is deterministic and spreads
+// well even when IDs share a common prefix.
+func ShortLabel(id trace.SpanID) string {
+	h := fnv.New32a()
+	h.Write(id[:])
+	return shortLabelEncoding.EncodeToString(h.Sum(nil))[:4]
+}
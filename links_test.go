@@ -0,0 +1,82 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shoenig/test/must"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTree_IDLinks(t *testing.T) {
+	spans := sampleSpans()
+	traceID := spans[0].SpanContext.TraceID().String()
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
+	must.StrNotContains(t, buf.String(), "\x1b]8;;")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithIDLinks(func(kind printer.IDKind, id string) string {
+		if kind != printer.TraceIDKind {
+			return ""
+		}
+		return "https://jaeger.example.com/trace/" + id
+	})))
+	output := buf.String()
+
+	must.StrContains(t, output, "\x1b]8;;https://jaeger.example.com/trace/"+traceID+"\x1b\\"+traceID+"\x1b]8;;\x1b\\")
+	must.StrNotContains(t, output, "jaeger.example.com/trace/"+spans[0].SpanContext.SpanID().String())
+
+	// Hyperlinks are zero-width, so box borders still line up.
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	for _, line := range lines[1:] {
+		must.Eq(t, lipgloss.Width(lines[0]), lipgloss.Width(line))
+	}
+}
+
+func TestPrintSpanTree_ShortIDs(t *testing.T) {
+	spans := sampleSpans()[:2]
+	spans[1].Links = []sdktrace.Link{{SpanContext: newSpan("enqueue", 9, 0, 0, time.Millisecond).SpanContext}}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithShortIDs(true)))
+	output := buf.String()
+
+	ids := []string{
+		spans[0].SpanContext.TraceID().String(),
+		spans[0].SpanContext.SpanID().String(),
+		spans[1].SpanContext.SpanID().String(),
+		spans[1].Links[0].SpanContext.SpanID().String(),
+	}
+	for _, id := range ids {
+		must.StrNotContains(t, output, id)
+		must.StrContains(t, output, "…"+id[len(id)-8:])
+	}
+	must.StrContains(t, output, "ParentSpan:  …"+ids[1][len(ids[1])-8:])
+
+	// Orphans name their missing parent the same way
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{spans[1]}, printer.WithShortIDs(true)))
+	must.StrContains(t, buf.String(), "(orphaned: parent …"+ids[1][len(ids[1])-8:]+" not captured)")
+}
+
+func TestShortLabel(t *testing.T) {
+	spans := sampleSpans()
+	id := spans[0].SpanContext.SpanID()
+
+	label := printer.ShortLabel(id)
+	must.Eq(t, 4, len(label))
+	must.Eq(t, label, printer.ShortLabel(id))
+	must.NotEq(t, label, printer.ShortLabel(spans[1].SpanContext.SpanID()))
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithShortHashLabels(true)))
+	must.StrContains(t, buf.String(), "root-span ["+label+"]")
+}
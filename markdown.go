@@ -0,0 +1,74 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// markdownEscaper backslash-escapes the characters that would otherwise be
+// interpreted as inline Markdown inside a list item.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"|", `\|`,
+)
+
+// WriteMarkdownList writes spans to w as a nested Markdown bullet list, one
+// "- name (duration)" item per span with two spaces of indentation per tree
+// level. Each span's attributes are listed as sub-bullets before its
+// children, formatted and redacted as in PrintSpanTree.
+func WriteMarkdownList(w io.Writer, spans []tracetest.SpanStub, opts ...Option) error {
+	return writeMarkdownList(w, newConfig(opts...), spans, false)
+}
+
+// PrintSpanTreeMarkdown writes spans to w as a nested Markdown bullet list
+// for pasting into issues, pull requests, and docs, where the colors of
+// PrintSpanTree are lost. The list is laid out as by WriteMarkdownList, with
+// the names of error spans prefixed by "⚠️".
+func PrintSpanTreeMarkdown(w io.Writer, spans []tracetest.SpanStub, opts ...Option) error {
+	return NewPrinter(opts...).PrintMarkdown(w, spans)
+}
+
+// PrintMarkdown writes spans to w as PrintSpanTreeMarkdown does, using p's
+// configuration.
+func (p *Printer) PrintMarkdown(w io.Writer, spans []tracetest.SpanStub) error {
+	return writeMarkdownList(w, writerConfig(p.cfg, w), spans, true)
+}
+
+// writeMarkdownList writes the list shared by WriteMarkdownList and
+// PrintSpanTreeMarkdown, marking error spans when markErrors is set.
+func writeMarkdownList(w io.Writer, cfg *Config, spans []tracetest.SpanStub, markErrors bool) error {
+	tree := newSpanTree(cfg, filterSpans(cfg, spans))
+
+	var b strings.Builder
+	var walk func(span tracetest.SpanStub, depth int)
+	walk = func(span tracetest.SpanStub, depth int) {
+		indent := strings.Repeat("  ", depth)
+		name := markdownEscaper.Replace(span.Name)
+		if markErrors && isErrorSpan(cfg, span) {
+			name = "⚠️ " + name
+		}
+		fmt.Fprintf(&b, "%s- %s (%s)\n", indent, name, formatSpanDuration(cfg, span))
+		for _, attr := range sortedAttributes(cfg, span.Attributes) {
+			fmt.Fprintf(&b, "%s  - %s\n", indent, markdownEscaper.Replace(string(attr.Key)+" = "+formatAttributeValue(cfg, attr)))
+		}
+		for _, child := range tree.childrenOf(span) {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range tree.roots {
+		walk(root, 0)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,81 @@
+package printer_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestWriteMarkdownList(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("GET /users", 1, 0, 0, 12*ms),
+		newSpan("db_query", 2, 1, 2*ms, 10*ms),
+		newSpan("row*scan", 3, 2, 3*ms, 4*ms),
+	}
+	spans[1].Attributes = []attribute.KeyValue{attribute.String("db.system", "postgres")}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.WriteMarkdownList(&buf, spans))
+
+	must.Eq(t, ""+
+		"- GET /users (12.0ms)\n"+
+		"  - db\\_query (8.00ms)\n"+
+		"    - db.system = postgres\n"+
+		"    - row\\*scan (1.00ms)\n",
+		buf.String())
+}
+
+func TestPrintSpanTreeMarkdown(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("checkout", 1, 0, 0, 30*ms),
+		newSpan("charge", 2, 1, 5*ms, 25*ms),
+		newSpan("card.verify", 3, 2, 6*ms, 10*ms),
+		newSpan("email", 4, 1, 25*ms, 29*ms),
+	}
+	spans[1].Status = sdktrace.Status{Code: codes.Error, Description: "declined"}
+	spans[2].Attributes = []attribute.KeyValue{attribute.String("card.brand", "visa")}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeMarkdown(&buf, spans))
+
+	must.Eq(t, ""+
+		"- checkout (30.0ms)\n"+
+		"  - ⚠️ charge (20.0ms)\n"+
+		"    - card.verify (4.00ms)\n"+
+		"      - card.brand = visa\n"+
+		"  - email (4.00ms)\n",
+		buf.String())
+}
+
+func TestPrintSpanTreeMarkdown_Attributes(t *testing.T) {
+	span := newSpan("login", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.String("user.name", "ada"),
+		attribute.String("password", "hunter2"),
+		attribute.StringSlice("roles", []string{"admin", "dev"}),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeMarkdown(&buf, []tracetest.SpanStub{span}))
+
+	must.Eq(t, ""+
+		"- login (1.00s)\n"+
+		"  - password = «redacted»\n"+
+		"  - roles = \\[admin, dev\\] (string\\[\\])\n"+
+		"  - user.name = ada\n",
+		buf.String())
+
+	buf.Reset()
+	must.NoError(t, printer.WriteMarkdownList(&buf, []tracetest.SpanStub{span}, printer.WithAttributeSort(false), printer.WithRedactedKeys()))
+	must.StrContains(t, buf.String(), "  - user.name = ada\n  - password = hunter2\n")
+}
@@ -0,0 +1,44 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// RenderMenu lists spans as a numbered menu, one line per span in the same
+// depth-first order as PrintSpanTree, with nesting shown by indentation.
+//
+// Along with the rendered menu it returns a map from each displayed number to
+// the SpanID it refers to, so callers can resolve a user's selection. Numbers
+// start at 1 and are stable for a given set of spans.
+func RenderMenu(spans []tracetest.SpanStub, opts ...Option) (string, map[int]string) {
+	cfg := newConfig(opts...)
+	spans = filterSpans(cfg, spans)
+	tree := newSpanTree(cfg, spans)
+
+	choices := make(map[int]string, len(spans))
+	ordered := tree.descendants(tree.roots)
+	width := len(fmt.Sprint(len(ordered)))
+
+	var lines []string
+	var walk func(span tracetest.SpanStub, depth int)
+	walk = func(span tracetest.SpanStub, depth int) {
+		n := len(choices) + 1
+		choices[n] = span.SpanContext.SpanID().String()
+
+		number := cfg.styles.label.Render(fmt.Sprintf("%*d.", width, n))
+		entry := cfg.styles.value.Render(fmt.Sprintf("%s (%s)", span.Name, formatSpanDuration(cfg, span)))
+		lines = append(lines, number+" "+strings.Repeat(cfg.Indent, depth)+entry)
+
+		for _, child := range tree.childrenOf(span) {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range tree.roots {
+		walk(root, 0)
+	}
+
+	return strings.Join(lines, "\n"), choices
+}
@@ -0,0 +1,39 @@
+package printer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderMenu(t *testing.T) {
+	spans := sampleSpans()
+
+	menu, choices := printer.RenderMenu(spans)
+	lines := strings.Split(menu, "\n")
+
+	must.SliceLen(t, len(spans), lines)
+	must.MapLen(t, len(spans), choices)
+
+	names := make(map[string]string, len(spans))
+	for _, s := range spans {
+		names[s.SpanContext.SpanID().String()] = s.Name
+	}
+
+	for i, line := range lines {
+		n := i + 1
+		must.StrHasPrefix(t, fmt.Sprintf("%d.", n), line)
+
+		spanID, ok := choices[n]
+		must.True(t, ok)
+		name, ok := names[spanID]
+		must.True(t, ok, must.Sprintf("choice %d resolves to unknown span %s", n, spanID))
+		must.StrContains(t, line, name)
+	}
+
+	must.StrContains(t, lines[3], "    child-span-3")
+}
@@ -0,0 +1,69 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// mermaidShortIDLength is how many hex digits of the span ID Mermaid node
+// labels show.
+const mermaidShortIDLength = 8
+
+// mermaidEscaper replaces characters that would end or break a quoted
+// Mermaid label with Mermaid's entity codes.
+var mermaidEscaper = strings.NewReplacer(
+	`"`, "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+	"\n", " ",
+)
+
+// PrintSpanTreeMermaid writes spans to w as a Mermaid "graph TD" flowchart,
+// for embedding in Markdown documentation and pull requests. Each span is a
+// node labeled with its name and the start of its span ID, with an edge from
+// each parent to each of its children. Error spans are given the "error"
+// class, styled red. Spans whose parent isn't among spans appear as roots.
+func PrintSpanTreeMermaid(w io.Writer, spans []tracetest.SpanStub) error {
+	cfg := newConfig()
+	tree := newSpanTree(cfg, spans)
+
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+
+	var failed []string
+	var walk func(span tracetest.SpanStub)
+	walk = func(span tracetest.SpanStub) {
+		id := mermaidNodeID(span)
+		spanID := span.SpanContext.SpanID().String()
+		fmt.Fprintf(&b, "    %s[\"%s<br/>%s\"]\n", id, mermaidEscaper.Replace(span.Name), spanID[:mermaidShortIDLength])
+		if isErrorSpan(cfg, span) {
+			failed = append(failed, id)
+		}
+		for _, child := range tree.childrenOf(span) {
+			fmt.Fprintf(&b, "    %s --> %s\n", id, mermaidNodeID(child))
+			walk(child)
+		}
+	}
+
+	for _, root := range tree.roots {
+		walk(root)
+	}
+
+	if len(failed) > 0 {
+		b.WriteString("    classDef error fill:#ffd7d7,stroke:#ff0000,color:#870000\n")
+		fmt.Fprintf(&b, "    class %s error\n", strings.Join(failed, ","))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidNodeID returns the Mermaid node identifier of span, derived from
+// its span ID so it is unique and contains only letters, digits, and
+// underscores.
+func mermaidNodeID(span tracetest.SpanStub) string {
+	return "span_" + span.SpanContext.SpanID().String()
+}
@@ -0,0 +1,31 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTreeMermaid(t *testing.T) {
+	spans := sampleSpans()
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeMermaid(&buf, spans))
+	output := buf.String()
+
+	must.True(t, strings.HasPrefix(output, "graph TD\n"))
+	must.Eq(t, 3, strings.Count(output, " --> "))
+
+	root := "span_" + spans[0].SpanContext.SpanID().String()
+	child3 := "span_" + spans[3].SpanContext.SpanID().String()
+	must.StrContains(t, output, root+`["root-span<br/>`+spans[0].SpanContext.SpanID().String()[:8]+`"]`)
+	must.StrContains(t, output, "span_"+spans[2].SpanContext.SpanID().String()+" --> "+child3)
+
+	// child-span-2 carries an error attribute.
+	must.StrContains(t, output, "classDef error")
+	must.StrContains(t, output, "class span_"+spans[2].SpanContext.SpanID().String()+" error")
+}
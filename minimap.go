@@ -0,0 +1,104 @@
+package printer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	// minimapSpanGlyph marks a cell occupied by at least one span.
+	minimapSpanGlyph = "■"
+
+	// minimapErrorGlyph marks a cell occupied by an error span.
+	minimapErrorGlyph = "✖"
+
+	// minimapEmptyGlyph fills cells with no spans.
+	minimapEmptyGlyph = " "
+)
+
+// minimapCell is the span chosen to represent one minimap cell.
+type minimapCell struct {
+	set      bool
+	failed   bool
+	duration time.Duration
+}
+
+// RenderMinimap returns a condensed bird's-eye view of spans: a grid in
+// which each span is a single character whose row is its tree depth and
+// whose column is its start time within the overall window.
+//
+// Cells are colored by the duration of the longest span they hold, and
+// spans that failed are drawn with a distinct glyph in the error color.
+// Spans deeper than the last row share it. The grid always has the
+// dimensions set by WithMinimapSize.
+func RenderMinimap(spans []tracetest.SpanStub, opts ...Option) string {
+	cfg := newConfig(opts...)
+	spans = filterSpans(cfg, spans)
+	tree := newSpanTree(cfg, spans)
+
+	cols, rows := max(cfg.MinimapColumns, 1), max(cfg.MinimapRows, 1)
+	grid := make([][]minimapCell, rows)
+	for i := range grid {
+		grid[i] = make([]minimapCell, cols)
+	}
+
+	windowStart := earliestStart(spans)
+	var longest time.Duration
+	for _, s := range spans {
+		d, _ := spanDuration(s)
+		longest = max(longest, d)
+	}
+	window := wallClock(spans)
+
+	var place func(span tracetest.SpanStub, depth int)
+	place = func(span tracetest.SpanStub, depth int) {
+		col := 0
+		if window > 0 {
+			col = int(float64(span.StartTime.Sub(windowStart)) / float64(window) * float64(cols-1))
+		}
+		col = min(max(col, 0), cols-1)
+
+		cell := &grid[min(depth, rows-1)][col]
+		duration, _ := spanDuration(span)
+		cell.set = true
+		cell.failed = cell.failed || isErrorSpan(cfg, span)
+		cell.duration = max(cell.duration, duration)
+
+		for _, child := range tree.childrenOf(span) {
+			place(child, depth+1)
+		}
+	}
+	for _, root := range tree.roots {
+		place(root, 0)
+	}
+
+	lines := make([]string, rows)
+	for i, row := range grid {
+		var b strings.Builder
+		for _, cell := range row {
+			switch {
+			case !cell.set:
+				b.WriteString(minimapEmptyGlyph)
+			case cell.failed:
+				b.WriteString(cfg.styles.errorHighlight.Render(minimapErrorGlyph))
+			default:
+				b.WriteString(heatStyle(cfg, cell.duration, longest).Render(minimapSpanGlyph))
+			}
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// heatStyle picks the heat style for d relative to the longest duration.
+func heatStyle(cfg *Config, d, longest time.Duration) lipgloss.Style {
+	heat := cfg.styles.heat
+	if longest <= 0 {
+		return heat[0]
+	}
+	i := int(float64(d) / float64(longest) * float64(len(heat)))
+	return heat[min(max(i, 0), len(heat)-1)]
+}
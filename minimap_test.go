@@ -0,0 +1,39 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderMinimap(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("ok", 2, 1, 0, 40*ms),
+		newSpan("failed", 3, 1, 50*ms, 90*ms),
+	}
+	spans[2].Status = sdktrace.Status{Code: codes.Error, Description: "boom"}
+
+	minimap := printer.RenderMinimap(spans, printer.WithMinimapSize(20, 4))
+	lines := strings.Split(minimap, "\n")
+
+	must.SliceLen(t, 4, lines)
+	for _, line := range lines {
+		must.Eq(t, 20, lipgloss.Width(line))
+	}
+
+	must.StrContains(t, lines[0], "■")
+	must.StrContains(t, lines[1], "■")
+	must.StrContains(t, lines[1], "✖")
+	must.Eq(t, 1, strings.Count(minimap, "✖"))
+	must.Eq(t, "", strings.TrimSpace(lines[3]))
+}
@@ -0,0 +1,65 @@
+package printer
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newickSanitizer replaces characters that have meaning in Newick notation,
+// along with whitespace, in span names.
+var newickSanitizer = strings.NewReplacer(
+	"(", "_", ")", "_", "[", "_", "]", "_",
+	":", "_", ";", "_", ",", "_", "'", "_",
+	" ", "_", "\t", "_", "\n", "_",
+)
+
+// WriteNewick writes the span hierarchy to w in Newick tree notation, using
+// sanitized span names as labels and span durations in seconds as branch
+// lengths, or zero for spans that have no duration. When there are several
+// roots they are joined under an unnamed top-level node. Nothing is written
+// when there are no spans, since an empty tree has no valid Newick form.
+func WriteNewick(w io.Writer, spans []tracetest.SpanStub) error {
+	tree := newSpanTree(newConfig(), spans)
+	if len(tree.roots) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	var write func(span tracetest.SpanStub)
+	write = func(span tracetest.SpanStub) {
+		if children := tree.childrenOf(span); len(children) > 0 {
+			b.WriteByte('(')
+			for i, child := range children {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				write(child)
+			}
+			b.WriteByte(')')
+		}
+		b.WriteString(newickSanitizer.Replace(span.Name))
+		b.WriteByte(':')
+		duration, _ := spanDuration(span)
+		b.WriteString(strconv.FormatFloat(duration.Seconds(), 'f', -1, 64))
+	}
+
+	if len(tree.roots) == 1 {
+		write(tree.roots[0])
+	} else {
+		b.WriteByte('(')
+		for i, root := range tree.roots {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			write(root)
+		}
+		b.WriteByte(')')
+	}
+	b.WriteString(";\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,49 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestWriteNewick(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, time.Second),
+		newSpan("GET /users", 2, 1, 0, 500*ms),
+		newSpan("child", 3, 1, 500*ms, 900*ms),
+		newSpan("leaf(a,b)", 4, 3, 600*ms, 900*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.WriteNewick(&buf, spans))
+	output := buf.String()
+
+	must.Eq(t, "(GET_/users:0.5,(leaf_a_b_:0.3)child:0.4)root:1;\n", output)
+
+	depth := 0
+	for _, r := range output {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		must.NonNegative(t, depth)
+	}
+	must.Zero(t, depth)
+	must.StrHasSuffix(t, ";\n", output)
+	must.True(t, strings.Contains(output, "leaf_a_b_"))
+}
+
+func TestWriteNewick_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	must.NoError(t, printer.WriteNewick(&buf, nil))
+	must.Eq(t, "", buf.String())
+}
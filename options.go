@@ -0,0 +1,1056 @@
+package printer
+
+import (
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Config holds the settings that control how span trees are rendered.
+//
+// A Config is never built directly by callers; instead it is assembled from
+// defaults plus any Option values passed to the printing functions.
+type Config struct {
+	// ShowScope renders the span's instrumentation scope (and its schema
+	// URL, when present) in each box.
+	ShowScope bool
+
+	// CriticalPath highlights the boxes of the spans on each trace's
+	// critical path and reports its duration after the trace's tree.
+	CriticalPath bool
+
+	// Efficiency shows each span's self time as a share of its duration.
+	Efficiency bool
+
+	// SelfTime shows the time each span spent outside its children.
+	SelfTime bool
+
+	// Legend prints a key to the colors and styles used by the trees ahead
+	// of them.
+	Legend bool
+
+	// ShowResource prints the resource attributes shared by every span once
+	// ahead of the trees, and any others in the boxes of the spans they
+	// belong to.
+	ShowResource bool
+
+	// ErrorTimeShare annotates the duration of each error span with its
+	// share of the trace's total error time.
+	ErrorTimeShare bool
+
+	// ExceptionDetails shows the recorded exception of spans with an Error
+	// status.
+	ExceptionDetails bool
+
+	// ChildWaterfall sketches each direct child's timing inside its parent's
+	// box.
+	ChildWaterfall bool
+
+	// TimelineWidth is the number of characters the timeline view's bars
+	// span.
+	TimelineWidth int
+
+	// MinBarWidth is the minimum width, in characters, of each bar drawn by
+	// the timeline view.
+	MinBarWidth int
+
+	// NoColor renders every style without colors or text attributes.
+	NoColor bool
+
+	// noColorSet records that NoColor was chosen explicitly, which turns
+	// off detecting it from the writer.
+	noColorSet bool
+
+	// IDLinks, when set, builds the URL that rendered trace and span IDs
+	// hyperlink to.
+	IDLinks func(kind IDKind, id string) string
+
+	// LabelWidth is the minimum width labels are padded to so values align.
+	LabelWidth int
+
+	// HideIDs omits the TraceID, SpanID, and ParentSpan lines.
+	HideIDs bool
+
+	// ShortIDs shows only the last few characters of trace and span IDs.
+	ShortIDs bool
+
+	// HideTimes omits the absolute Start Time and End Time lines.
+	HideTimes bool
+
+	// DurationBuckets, when non-nil, replaces exact durations with the label
+	// of the coarse range they fall into.
+	DurationBuckets []time.Duration
+
+	// DurationFormat renders durations that aren't mapped into buckets.
+	DurationFormat func(time.Duration) string
+
+	// MaxAttributeValueLines caps how many lines of a multi-line attribute
+	// value are shown. Zero shows every line.
+	MaxAttributeValueLines int
+
+	// MaxAttributeValueLength caps how many runes of an attribute value are
+	// shown. Zero shows the whole value.
+	MaxAttributeValueLength int
+
+	// MaxEvents caps how many of each span's events are listed, earliest
+	// first, noting how many more were recorded. Zero lists every event.
+	MaxEvents int
+
+	// PrematureCloseMarker warns on spans that ended before one of their
+	// children did.
+	PrematureCloseMarker bool
+
+	// OverlapMarker notes on each span's name line whether it overlaps any
+	// of its siblings in time.
+	OverlapMarker bool
+
+	// RootMarker prefixes the name of each root span with "◉ ROOT".
+	RootMarker bool
+
+	// SiblingRank ranks each span's duration among its siblings on its name
+	// line.
+	SiblingRank bool
+
+	// MaxDepth is the deepest level whose spans are drawn; the descendants
+	// of spans at that depth are summarized instead. Zero draws every
+	// level.
+	MaxDepth int
+
+	// MaxNesting is the number of box levels rendered before deeper spans
+	// are replaced by a note. Zero removes the guard.
+	MaxNesting int
+
+	// AttributeDepthLimit is the deepest level at which attributes are
+	// listed in full; deeper spans only show an attribute count. Zero
+	// lists attributes at every depth.
+	AttributeDepthLimit int
+
+	// RedactedKeys name the attributes whose values are hidden.
+	RedactedKeys []string
+
+	// ErrorDetector, when set, decides which attributes are error-related
+	// in place of the built-in heuristic.
+	ErrorDetector func(key string, val interface{}) bool
+
+	// AttributeFilter, when non-empty, limits the attributes listed for
+	// each span to those with these keys, in this order.
+	AttributeFilter []string
+
+	// AttributeSort lists attributes sorted by key rather than in the order
+	// they were recorded.
+	AttributeSort bool
+
+	// SemanticAttributes maps attribute keys to the semantic type of their
+	// integer values: "duration-ns", "timestamp-ns", or "timestamp-ms".
+	SemanticAttributes map[string]string
+
+	// ThousandsSeparator groups the digits of numeric attribute values.
+	ThousandsSeparator bool
+
+	// ActiveOnly keeps only spans that have not ended, plus their
+	// ancestors.
+	ActiveOnly bool
+
+	// Highlight, when set, marks every case-insensitive occurrence of it in
+	// span names and attribute values.
+	Highlight string
+
+	// NameFilter, when set, keeps only spans whose name matches this
+	// path.Match pattern, plus their ancestors.
+	NameFilter string
+
+	// MinDuration hides spans shorter than it, unless they have a
+	// descendant that is shown.
+	MinDuration time.Duration
+
+	// ShortHashLabels appends each span's ShortLabel to its name line.
+	ShortHashLabels bool
+
+	// IndicatorGlyphs marks the name line of spans that have events or
+	// links with EventGlyph and LinkGlyph respectively.
+	IndicatorGlyphs bool
+
+	// EventGlyph marks spans with events when IndicatorGlyphs is set.
+	EventGlyph string
+
+	// LinkGlyph marks spans with links when IndicatorGlyphs is set.
+	LinkGlyph string
+
+	// ChildSort orders the children of each span, and the roots. The zero
+	// value orders them by start time.
+	ChildSort ChildSort
+
+	// StableSiblingOrder breaks start-time ties between siblings by name and
+	// then SpanID.
+	StableSiblingOrder bool
+
+	// Summary reports each trace's span count, error count, wall-clock
+	// duration, and slowest span after its tree.
+	Summary bool
+
+	// ServiceSummary reports the distinct services involved after each
+	// trace.
+	ServiceSummary bool
+
+	// FanOutSummary reports the maximum and average number of direct
+	// children per parent span after each trace.
+	FanOutSummary bool
+
+	// DepthHistogram reports how many spans sit at each tree depth after
+	// each trace.
+	DepthHistogram bool
+
+	// EventBookends reports the earliest and latest event across each
+	// trace.
+	EventBookends bool
+
+	// TruncationDetection warns after each trace that looks like it was
+	// captured mid-flight.
+	TruncationDetection bool
+
+	// HeaderTemplate and FooterTemplate, when set, are text/template
+	// sources executed with a TemplateData and written before and after
+	// the whole output.
+	HeaderTemplate, FooterTemplate string
+
+	// FlagPredicate, when set, selects spans to list in a "Flagged spans"
+	// section ahead of the trees.
+	FlagPredicate func(tracetest.SpanStub) bool
+
+	// PageColumns, when greater than one, lays root trees out side by side
+	// in this many columns.
+	PageColumns int
+
+	// MinimapColumns and MinimapRows set the dimensions of the minimap.
+	MinimapColumns, MinimapRows int
+
+	// SVGWidth and SVGRowHeight set, in pixels, the width of the SVG
+	// waterfall and the height of each of its rows.
+	SVGWidth, SVGRowHeight int
+
+	// BorderColor, LabelColor, ValueColor, and ErrorColor, when set,
+	// replace the default colors of box borders, labels, values, and error
+	// highlights respectively.
+	BorderColor, LabelColor, ValueColor, ErrorColor lipgloss.Color
+
+	// ErrorBorderColor, when set, replaces the error color as the border
+	// color of failed spans' boxes.
+	ErrorBorderColor lipgloss.Color
+
+	// EpochOffset, when set, appends the span's start time as a Unix epoch
+	// value in this unit to the Start Time line.
+	EpochOffset EpochUnit
+
+	// MaxWidth, when positive, caps the width of the span boxes, wrapping
+	// content that doesn't fit.
+	MaxWidth int
+
+	// Indent is prepended once per level of nesting to child boxes, and to
+	// the entries of the one-line-per-span views.
+	Indent string
+
+	// TimeFormat is the layout, as accepted by time.Time.Format, used to
+	// display span, event, and timestamp attribute times.
+	TimeFormat string
+
+	// RelativeTimes shows start and end times as offsets from the earliest
+	// span's start instead of as absolute times.
+	RelativeTimes bool
+
+	// Renderer, when set, renders every style instead of lipgloss's
+	// package-global default renderer.
+	Renderer *lipgloss.Renderer
+
+	// styles is resolved from the fields above once options are applied.
+	styles styleSet
+
+	// highlight matches Highlight, ignoring case. It is resolved along with
+	// styles.
+	highlight *regexp.Regexp
+
+	// headerTemplate and footerTemplate are HeaderTemplate and
+	// FooterTemplate parsed along with styles. templateErr holds the error
+	// from parsing either, which every render returns.
+	headerTemplate, footerTemplate *template.Template
+	templateErr                    error
+
+	// sharedResource holds the resource attributes common to every span
+	// being printed. It is filled in per render when ShowResource is set.
+	sharedResource map[attribute.Key]attribute.Value
+
+	// errorShares maps the SpanID of each error span to its share of its
+	// trace's error time. It is filled in per render when ErrorTimeShare is
+	// set.
+	errorShares map[string]float64
+
+	// fastHidden counts, by SpanID, the spans hidden below each shown span
+	// by MinDuration. It is filled in per render by filterSpans.
+	fastHidden map[string]int
+
+	// criticalSpans holds the SpanIDs of the spans on each trace's critical
+	// path. It is filled in per render when CriticalPath is set.
+	criticalSpans map[string]bool
+
+	// traceStart is the earliest start time among the spans being printed,
+	// which relative times are measured from. It is filled in per render
+	// when RelativeTimes is set.
+	traceStart time.Time
+}
+
+// defaultMaxNesting bounds how many boxes deep the tree is drawn. Each level
+// widens every line beneath it, so pathologically deep traces would otherwise
+// produce output quadratic in their depth.
+const defaultMaxNesting = 50
+
+// Option configures a Config.
+type Option func(*Config)
+
+// defaultConfig returns the Config matching the printer's historical output.
+func defaultConfig() *Config {
+	return &Config{
+		EventGlyph: "⚑",
+		LinkGlyph:  "🔗",
+
+		MaxNesting: defaultMaxNesting,
+
+		Indent:         childIndent,
+		TimeFormat:     timeFormat,
+		DurationFormat: humanDuration,
+
+		AttributeSort: true,
+		RedactedKeys:  defaultRedactedKeys,
+
+		TimelineWidth: defaultTimelineWidth,
+
+		MinimapColumns: 60,
+		MinimapRows:    8,
+
+		SVGWidth:     800,
+		SVGRowHeight: 20,
+	}
+}
+
+// newConfig resolves opts over the default configuration.
+func newConfig(opts ...Option) *Config {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	cfg.styles = newStyleSet(cfg)
+	if cfg.Highlight != "" {
+		cfg.highlight = regexp.MustCompile("(?i)" + regexp.QuoteMeta(cfg.Highlight))
+	}
+	cfg.headerTemplate, cfg.footerTemplate, cfg.templateErr = parseTemplates(cfg)
+	return cfg
+}
+
+// writerConfig returns a copy of cfg for a single render written to w, so
+// the state filled in during the render doesn't outlive it. Unless color
+// was configured explicitly, with WithNoColor or a custom renderer, colors
+// are turned off when w can't display them, such as when it is a file, a
+// pipe, or an in-memory buffer.
+func writerConfig(cfg *Config, w io.Writer) *Config {
+	c := *cfg
+	if !c.noColorSet && c.Renderer == nil && !c.NoColor && !supportsColor(w) {
+		c.NoColor = true
+		c.styles = newStyleSet(&c)
+	}
+	return &c
+}
+
+// WithNoColor renders every style as plain text, without ANSI escape
+// sequences, while still drawing box borders. This is useful when output is
+// captured in CI logs or redirected to a file.
+//
+// Without this option, colors are turned off automatically when the writer
+// isn't a terminal. WithNoColor(false) turns that detection off, leaving
+// colors to the renderer in use.
+func WithNoColor(enabled bool) Option {
+	return func(c *Config) {
+		c.NoColor = enabled
+		c.noColorSet = true
+	}
+}
+
+// WithShowScope toggles the "Scope:" line, which shows the instrumentation
+// scope name and version, followed by a "Schema URL:" line when the scope
+// carries one.
+func WithShowScope(show bool) Option {
+	return func(c *Config) {
+		c.ShowScope = show
+	}
+}
+
+// WithCriticalPath highlights each trace's critical path, the root-to-leaf
+// chain that accounts for the most time, by drawing the boxes of the spans
+// along it with a thick border in a distinct color. A "critical path: X (of
+// Y total)" line after each trace compares the time spent along the chain
+// with the trace's wall-clock duration.
+func WithCriticalPath(enabled bool) Option {
+	return func(c *Config) {
+		c.CriticalPath = enabled
+	}
+}
+
+// WithTimelineWidth sets the number of characters the bars drawn by
+// PrintSpanTreeTimeline span. Non-positive widths keep the default of 80.
+func WithTimelineWidth(n int) Option {
+	return func(c *Config) {
+		if n > 0 {
+			c.TimelineWidth = n
+		}
+	}
+}
+
+// WithMinBarWidth ensures every timeline bar is at least n characters wide,
+// so instantaneous spans (start == end) remain visible as a marker.
+func WithMinBarWidth(n int) Option {
+	return func(c *Config) {
+		c.MinBarWidth = n
+	}
+}
+
+// defaultDurationBuckets are the coarse ranges used by snapshot mode.
+var defaultDurationBuckets = []time.Duration{
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// WithSnapshotMode is a preset for snapshot tests: it disables colors, omits
+// IDs and absolute times, and buckets durations into coarse ranges, so the
+// output depends only on structure, names, attributes, and rough timing.
+func WithSnapshotMode() Option {
+	return func(c *Config) {
+		c.NoColor = true
+		c.HideIDs = true
+		c.HideTimes = true
+		if c.DurationBuckets == nil {
+			c.DurationBuckets = defaultDurationBuckets
+		}
+	}
+}
+
+// WithDeterministic is a preset for golden-file tests that, unlike
+// WithSnapshotMode, keeps exact durations: it disables colors, sorts
+// attributes and same-time siblings, shows times relative to the earliest
+// span's start, drops epoch values, and restores the default duration
+// format, so the output is the same wherever and whenever the spans were
+// recorded. Options given after it can still override these.
+// WithDeterministic(false) changes nothing.
+func WithDeterministic(enabled bool) Option {
+	return func(c *Config) {
+		if !enabled {
+			return
+		}
+		c.NoColor = true
+		c.noColorSet = true
+		c.AttributeSort = true
+		c.StableSiblingOrder = true
+		c.RelativeTimes = true
+		c.EpochOffset = 0
+		c.DurationFormat = humanDuration
+	}
+}
+
+// WithMaxAttributeValueLines shows at most n lines of each multi-line
+// attribute value (stack traces, JSON), noting how many lines were omitted
+// and aligning continuation lines under the value column.
+func WithMaxAttributeValueLines(n int) Option {
+	return func(c *Config) {
+		c.MaxAttributeValueLines = n
+	}
+}
+
+// WithMaxAttributeValueLength shows at most n runes of each attribute value,
+// replacing the rest with a note such as "… (+480 chars)". Zero shows whole
+// values.
+func WithMaxAttributeValueLength(n int) Option {
+	return func(c *Config) {
+		c.MaxAttributeValueLength = n
+	}
+}
+
+// WithMaxEvents lists the first n events of each span, ordered by time, and
+// notes how many more there are so event-heavy spans stay readable.
+func WithMaxEvents(n int) Option {
+	return func(c *Config) {
+		c.MaxEvents = n
+	}
+}
+
+// WithHighlight marks every occurrence of substr in span names and
+// attribute values in reverse video, so values such as a request ID stand
+// out in a large trace. Matching ignores case.
+func WithHighlight(substr string) Option {
+	return func(c *Config) {
+		c.Highlight = substr
+	}
+}
+
+// WithNameFilter keeps only spans whose name matches pattern, such as
+// "db.*" or "http.*", along with their ancestors so the matches keep their
+// context. Patterns use path.Match syntax; a malformed pattern matches
+// nothing. When no span matches, nothing is printed.
+func WithNameFilter(pattern string) Option {
+	return func(c *Config) {
+		c.NameFilter = pattern
+	}
+}
+
+// WithMinDuration hides spans that took less than d, keeping any that have
+// a descendant at least that long so the tree stays connected. Spans without
+// a duration, such as those still in progress, are never hidden. Each shown span notes how many spans were
+// hidden below it, as in "(3 fast spans hidden)".
+func WithMinDuration(d time.Duration) Option {
+	return func(c *Config) {
+		c.MinDuration = d
+	}
+}
+
+// WithActiveOnly keeps only in-progress spans (those with a zero EndTime)
+// and their ancestors, showing what is currently in flight.
+func WithActiveOnly(enabled bool) Option {
+	return func(c *Config) {
+		c.ActiveOnly = enabled
+	}
+}
+
+// WithIndicatorGlyphs toggles glyphs on each span's name line that show at a
+// glance whether it has events ("⚑") or links ("🔗").
+func WithIndicatorGlyphs(enabled bool) Option {
+	return func(c *Config) {
+		c.IndicatorGlyphs = enabled
+	}
+}
+
+// WithGlyphs overrides the event and link indicator glyphs, e.g. with ASCII
+// such as "E" and "L" for terminals without Unicode support.
+func WithGlyphs(event, link string) Option {
+	return func(c *Config) {
+		c.EventGlyph = event
+		c.LinkGlyph = link
+	}
+}
+
+// WithStableSiblingOrder breaks ties between siblings that start at the same
+// instant (common with mocked clocks) by span name and then SpanID, making
+// the output fully deterministic.
+func WithStableSiblingOrder(enabled bool) Option {
+	return func(c *Config) {
+		c.StableSiblingOrder = enabled
+	}
+}
+
+// ChildSort is an order for sibling spans.
+type ChildSort int
+
+const (
+	// SortByStartTime orders siblings from the earliest start to the
+	// latest. It is the default.
+	SortByStartTime ChildSort = iota
+
+	// SortByEndTime orders siblings from the latest end to the earliest,
+	// so the spans that finished last come first.
+	SortByEndTime
+
+	// SortByDuration orders siblings from the longest to the shortest.
+	SortByDuration
+
+	// SortByName orders siblings by name.
+	SortByName
+)
+
+// WithChildSort orders the children of each span, and the roots, by mode
+// instead of by start time, e.g. SortByDuration to list the slowest
+// children first when chasing latency. Ties keep start-time order.
+func WithChildSort(mode ChildSort) Option {
+	return func(c *Config) {
+		c.ChildSort = mode
+	}
+}
+
+// WithMinimapSize sets the number of columns and rows in the minimap drawn
+// by RenderMinimap.
+func WithMinimapSize(columns, rows int) Option {
+	return func(c *Config) {
+		c.MinimapColumns = columns
+		c.MinimapRows = rows
+	}
+}
+
+// WithSVGSize sets the width of the waterfall drawn by WriteSVG and the
+// height of each span's row, both in pixels. The time scale follows from the
+// width, since the trace's full duration always spans it.
+func WithSVGSize(width, rowHeight int) Option {
+	return func(c *Config) {
+		c.SVGWidth = width
+		c.SVGRowHeight = rowHeight
+	}
+}
+
+// WithSummary toggles a "summary: N spans, N errors, D total, slowest 'x'
+// (D self)" line after each trace. The slowest span is the one with the
+// most self time, so a parent that only waits on its children isn't picked
+// over the child doing the work.
+func WithSummary(enabled bool) Option {
+	return func(c *Config) {
+		c.Summary = enabled
+	}
+}
+
+// WithServiceSummary toggles a "services: N (a, b, ...)" line after each
+// trace, listing the distinct service.name resource values in the order they
+// first appear. Spans without a service name count as "(unknown)".
+func WithServiceSummary(enabled bool) Option {
+	return func(c *Config) {
+		c.ServiceSummary = enabled
+	}
+}
+
+// WithLegend toggles a "Legend:" box ahead of the trees that shows each
+// color and style in use, such as labels, values, and error highlights,
+// next to what it marks. The samples are drawn in the configured colors, so
+// the legend matches the output it describes.
+func WithLegend(enabled bool) Option {
+	return func(c *Config) {
+		c.Legend = enabled
+	}
+}
+
+// WithShowResource toggles printing span resources. Attributes shared by
+// every span's resource, such as service.name in a single-service capture,
+// are printed once in a "Resource:" block ahead of the trees; attributes
+// that differ from span to span are listed in a "Resource:" section of each
+// span's box.
+func WithShowResource(show bool) Option {
+	return func(c *Config) {
+		c.ShowResource = show
+	}
+}
+
+// WithErrorTimeShare annotates the Duration line of each error span with its
+// share of the time spent in all error spans of its trace, such as
+// "(12% of error time)", to show which failure cost the most.
+func WithErrorTimeShare(enabled bool) Option {
+	return func(c *Config) {
+		c.ErrorTimeShare = enabled
+	}
+}
+
+// WithPageColumns lays root trees out in n columns to fill a page, for
+// printing or PDF export. Each tree goes to the column that is currently
+// shortest, so columns end up with similar heights. Trace summaries follow
+// the columns rather than each trace. Values below two keep the single
+// column layout.
+func WithPageColumns(n int) Option {
+	return func(c *Config) {
+		c.PageColumns = n
+	}
+}
+
+// WithRenderer renders all styles through r rather than the lipgloss default
+// renderer, which detects its color profile from stdout. This gives callers
+// control over the color profile and isolates parallel tests from global
+// state.
+func WithRenderer(r *lipgloss.Renderer) Option {
+	return func(c *Config) {
+		c.Renderer = r
+	}
+}
+
+// WithBorderColor draws span box borders in color instead of the default
+// purple.
+func WithBorderColor(color lipgloss.Color) Option {
+	return func(c *Config) {
+		c.BorderColor = color
+	}
+}
+
+// WithLabelColor renders labels such as "Span Name:" in color instead of the
+// default pink. Labels stay bold.
+func WithLabelColor(color lipgloss.Color) Option {
+	return func(c *Config) {
+		c.LabelColor = color
+	}
+}
+
+// WithValueColor renders values in color instead of the default light gray.
+func WithValueColor(color lipgloss.Color) Option {
+	return func(c *Config) {
+		c.ValueColor = color
+	}
+}
+
+// WithErrorColor highlights error attributes, statuses, warnings, and the
+// borders of failed spans in color instead of the default red, for themes
+// where red is hard to read.
+func WithErrorColor(color lipgloss.Color) Option {
+	return func(c *Config) {
+		c.ErrorColor = color
+	}
+}
+
+// WithErrorBorderColor draws the box borders of failed spans in color
+// instead of the error color. A span has failed when its status is Error
+// or one of its attributes is error-related.
+func WithErrorBorderColor(color lipgloss.Color) Option {
+	return func(c *Config) {
+		c.ErrorBorderColor = color
+	}
+}
+
+// EpochUnit is the unit of an epoch timestamp.
+type EpochUnit int
+
+const (
+	// EpochMillis is milliseconds since the Unix epoch.
+	EpochMillis EpochUnit = iota + 1
+
+	// EpochMicros is microseconds since the Unix epoch.
+	EpochMicros
+
+	// EpochNanos is nanoseconds since the Unix epoch.
+	EpochNanos
+)
+
+// WithPrematureCloseMarker toggles an "⚠ ended before child 'X'" warning on
+// spans that ended before one of their children, a sign the parent was
+// closed too early or the child is a detached continuation. The child that
+// ended last is named.
+func WithPrematureCloseMarker(enabled bool) Option {
+	return func(c *Config) {
+		c.PrematureCloseMarker = enabled
+	}
+}
+
+// WithOverlapMarker toggles a note on the name line of each span that has
+// siblings: "(concurrent with N siblings)" when its time range overlaps N of
+// them, or "(serial)" when it overlaps none. It helps spot both concurrency
+// and work that was unexpectedly serialized.
+func WithOverlapMarker(enabled bool) Option {
+	return func(c *Config) {
+		c.OverlapMarker = enabled
+	}
+}
+
+// WithRootMarker toggles a "◉ ROOT" prefix on the name of each span without a
+// parent, so entry points stand out in multi-root output.
+func WithRootMarker(enabled bool) Option {
+	return func(c *Config) {
+		c.RootMarker = enabled
+	}
+}
+
+// WithEpochOffset appends "(epoch: N)" to each span's Start Time line, with
+// N the start time since the Unix epoch in unit, for correlating spans with
+// systems that log raw epoch values.
+func WithEpochOffset(unit EpochUnit) Option {
+	return func(c *Config) {
+		c.EpochOffset = unit
+	}
+}
+
+// WithMaxWidth keeps span boxes, including their borders, within cols
+// columns, such as the width of a narrow terminal. Boxes whose content is
+// too wide are narrowed and long values wrapped; nested boxes are narrowed
+// further to leave room for the boxes enclosing them.
+func WithMaxWidth(cols int) Option {
+	return func(c *Config) {
+		c.MaxWidth = cols
+	}
+}
+
+// WithIndent sets the string each level of nesting is indented by, such as
+// four spaces or a guide like "│ ". The default is two spaces.
+func WithIndent(s string) Option {
+	return func(c *Config) {
+		c.Indent = s
+	}
+}
+
+// WithTimeFormat sets the layout used to display times, such as "15:04:05"
+// to leave out the date. The layout is passed to time.Time.Format as is.
+func WithTimeFormat(layout string) Option {
+	return func(c *Config) {
+		c.TimeFormat = layout
+	}
+}
+
+// WithRelativeTimes shows the Start Time and End Time of each span as an
+// offset from the earliest span's start, such as "+0ms" or "+12.3ms",
+// rather than as absolute times.
+func WithRelativeTimes(enabled bool) Option {
+	return func(c *Config) {
+		c.RelativeTimes = enabled
+	}
+}
+
+// WithTruncationDetection toggles a "⚠ trace may be truncated" note after
+// any trace where a descendant ends after its root or spans reference a
+// parent that wasn't captured, along with the reason.
+func WithTruncationDetection(enabled bool) Option {
+	return func(c *Config) {
+		c.TruncationDetection = enabled
+	}
+}
+
+// WithDurationFormat sets the function used to render durations wherever
+// they are shown, in place of the default, which rounds to three significant
+// figures (e.g. "1.50s", "12.3ms", "850µs"). Durations mapped into buckets by
+// WithDurationBuckets or WithSnapshotMode are still shown as bucket labels.
+// A nil format keeps the default.
+func WithDurationFormat(format func(time.Duration) string) Option {
+	return func(c *Config) {
+		if format != nil {
+			c.DurationFormat = format
+		}
+	}
+}
+
+// WithDurationBuckets sets the coarse ranges durations are mapped into, shown
+// as labels such as "[<10ms]" or "[>=100ms]". Bounds may be given in any
+// order. Buckets apply wherever durations are shown, and override the
+// defaults used by WithSnapshotMode.
+func WithDurationBuckets(buckets []time.Duration) Option {
+	return func(c *Config) {
+		c.DurationBuckets = slices.Clone(buckets)
+		slices.Sort(c.DurationBuckets)
+	}
+}
+
+// WithFlagSection lists every span matching predicate in a prominent
+// "Flagged spans" section before the trees, each with the path of span names
+// leading to it. Flagged spans still appear in the tree as usual.
+func WithFlagSection(predicate func(tracetest.SpanStub) bool) Option {
+	return func(c *Config) {
+		c.FlagPredicate = predicate
+	}
+}
+
+// WithHeaderTemplate writes a banner before the output by executing tmpl, a
+// text/template, with a TemplateData describing all the spans printed, for
+// example "Spans: {{.SpanCount}}". The template is parsed once, when the
+// options are applied; if it fails to parse or execute, PrintSpanTree
+// returns the error.
+func WithHeaderTemplate(tmpl string) Option {
+	return func(c *Config) {
+		c.HeaderTemplate = tmpl
+	}
+}
+
+// WithFooterTemplate is like WithHeaderTemplate, but writes its banner after
+// the output.
+func WithFooterTemplate(tmpl string) Option {
+	return func(c *Config) {
+		c.FooterTemplate = tmpl
+	}
+}
+
+// WithFanOutSummary toggles a "max fan-out: N, avg fan-out: X" line after
+// each trace. The average is taken over spans that have at least one child.
+func WithFanOutSummary(enabled bool) Option {
+	return func(c *Config) {
+		c.FanOutSummary = enabled
+	}
+}
+
+// WithDepthHistogram toggles a "depth histogram: L0=1 L1=3 L2=1" line after
+// each trace counting the spans at each depth, with roots at L0.
+func WithDepthHistogram(enabled bool) Option {
+	return func(c *Config) {
+		c.DepthHistogram = enabled
+	}
+}
+
+// WithRedactedKeys renders the values of attributes with these keys as
+// "«redacted»" so secrets don't leak into logs, while their keys still show.
+// A key also matches attributes whose last dot-separated segment equals it,
+// ignoring case, so "authorization" covers
+// "http.request.header.authorization".
+//
+// The keys replace DefaultRedactedKeys, which cover common credentials; call
+// WithRedactedKeys with no keys to redact nothing.
+func WithRedactedKeys(keys ...string) Option {
+	return func(c *Config) {
+		c.RedactedKeys = slices.Clone(keys)
+	}
+}
+
+// WithErrorDetector replaces the built-in check for error-related
+// attributes with detect, which is called with each attribute's key and
+// value. Attributes it reports are highlighted, and spans that have one are
+// treated as failed. A nil detector restores the built-in check.
+func WithErrorDetector(detect func(key string, val interface{}) bool) Option {
+	return func(c *Config) {
+		c.ErrorDetector = detect
+	}
+}
+
+// WithAttributeFilter lists only the span attributes whose keys are among
+// keys, in the order given, for every span in the tree. With no keys, every
+// attribute is listed.
+func WithAttributeFilter(keys ...string) Option {
+	return func(c *Config) {
+		c.AttributeFilter = slices.Clone(keys)
+	}
+}
+
+// WithAttributeSort toggles listing attributes sorted by key, which is the
+// default since the SDK doesn't guarantee attribute order. Pass false to
+// keep the order in which attributes were recorded.
+func WithAttributeSort(enabled bool) Option {
+	return func(c *Config) {
+		c.AttributeSort = enabled
+	}
+}
+
+// WithSemanticAttributes renders the integer values of the given attribute
+// keys as the semantic type each maps to: "duration-ns" values as durations
+// ("250ms"), and "timestamp-ns" or "timestamp-ms" values as UTC timestamps.
+// Other types and non-integer values are rendered as usual.
+func WithSemanticAttributes(types map[string]string) Option {
+	return func(c *Config) {
+		c.SemanticAttributes = maps.Clone(types)
+	}
+}
+
+// WithThousandsSeparator formats int and float attribute values with digit
+// grouping ("1,048,576"). Attributes whose keys look like identifiers (such
+// as "user.id" or "request_id") and non-numeric values are left alone.
+func WithThousandsSeparator(enabled bool) Option {
+	return func(c *Config) {
+		c.ThousandsSeparator = enabled
+	}
+}
+
+// WithChildWaterfall adds a "Child Timing:" section to each parent box with a
+// mini-waterfall row per direct child, showing when each child ran relative
+// to the parent's window so intra-span concurrency is visible at a glance.
+func WithChildWaterfall(enabled bool) Option {
+	return func(c *Config) {
+		c.ChildWaterfall = enabled
+	}
+}
+
+// WithIDLinks wraps rendered trace and span IDs in OSC 8 hyperlinks, which
+// modern terminals make clickable. The url function builds each link, e.g. a
+// deep link into Jaeger; returning "" leaves that ID as plain text.
+func WithIDLinks(url func(kind IDKind, id string) string) Option {
+	return func(c *Config) {
+		c.IDLinks = url
+	}
+}
+
+// WithShortIDs shows trace and span IDs, including parent and link IDs, by
+// their last 8 hex characters after a leading "…", e.g. "…0e0f1011", so they
+// don't crowd out the rest of each box. That is usually enough to tell the
+// spans in a test apart. Hyperlinks from WithIDLinks still use the full IDs.
+func WithShortIDs(enabled bool) Option {
+	return func(c *Config) {
+		c.ShortIDs = enabled
+	}
+}
+
+// WithSiblingRank annotates each span that has siblings with its duration
+// rank among them, e.g. "(slowest of 3)" or "(2nd of 3 by duration)", so the
+// bottleneck among peers is obvious.
+func WithSiblingRank(enabled bool) Option {
+	return func(c *Config) {
+		c.SiblingRank = enabled
+	}
+}
+
+// WithAttributeDepthLimit shows full attributes only for spans at depth ≤ n,
+// where roots are at depth 0, and collapses the attributes of deeper spans
+// to a "(k attributes)" summary. Zero, the default, disables the limit.
+func WithAttributeDepthLimit(n int) Option {
+	return func(c *Config) {
+		c.AttributeDepthLimit = n
+	}
+}
+
+// WithEventBookends toggles a line after each trace naming its earliest and
+// latest events across all spans, along with their times, which is handy
+// when assembling incident timelines.
+func WithEventBookends(enabled bool) Option {
+	return func(c *Config) {
+		c.EventBookends = enabled
+	}
+}
+
+// WithMaxDepth draws spans down to depth n, with roots at depth 0, and
+// replaces everything below with a line summarizing the hidden spans, such
+// as "… (12 spans, 340ms, 0 errors) hidden (max depth reached)". Zero, the
+// default, draws the whole tree.
+func WithMaxDepth(n int) Option {
+	return func(c *Config) {
+		c.MaxDepth = n
+	}
+}
+
+// WithMaxNesting sets how many levels of nested boxes are drawn before the
+// remaining descendants are replaced by a "nesting limit reached" note. It
+// defaults to 50, which keeps pathologically deep traces (thousands of
+// levels) from producing unbounded output; zero removes the guard.
+func WithMaxNesting(n int) Option {
+	return func(c *Config) {
+		c.MaxNesting = n
+	}
+}
+
+// WithLabelWidth right-pads each "Label:" in a box to n columns so the values
+// after it start in the same column. Labels wider than n are left as is; a
+// width of 22 fits every label the printer emits.
+func WithLabelWidth(n int) Option {
+	return func(c *Config) {
+		c.LabelWidth = n
+	}
+}
+
+// WithSelfTime adds a "Self Time:" line after each span's duration showing
+// the time not covered by any of its children, which is where the span
+// itself spent its time. Children that overlap are only counted once.
+func WithSelfTime(enabled bool) Option {
+	return func(c *Config) {
+		c.SelfTime = enabled
+	}
+}
+
+// WithEfficiency adds an "Efficiency:" line to each span showing its self
+// time (time not covered by any child) as a percentage of its duration, so
+// spans that mostly wait on children stand out. Spans with no duration are
+// skipped.
+func WithEfficiency(enabled bool) Option {
+	return func(c *Config) {
+		c.Efficiency = enabled
+	}
+}
+
+// WithExceptionDetails adds a highlighted "Error:" block to spans with an
+// Error status, showing the exception type and message from the span's
+// "exception" event followed by the first few lines of its stacktrace.
+func WithExceptionDetails(enabled bool) Option {
+	return func(c *Config) {
+		c.ExceptionDetails = enabled
+	}
+}
+
+// WithShortHashLabels appends a short stable label derived from each span's
+// SpanID (see ShortLabel) to its name line, e.g. "checkout [a7k2]", for
+// referring to spans in notes without the long hex IDs.
+func WithShortHashLabels(enabled bool) Option {
+	return func(c *Config) {
+		c.ShortHashLabels = enabled
+	}
+}
@@ -0,0 +1,77 @@
+package printer_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+// relabel returns a copy of spans moved by offset in time and with every
+// trace and span ID XORed with salt, preserving the parent relationships.
+func relabel(spans []tracetest.SpanStub, offset time.Duration, salt byte) []tracetest.SpanStub {
+	remap := func(sc trace.SpanContext) trace.SpanContext {
+		if !sc.IsValid() {
+			return sc
+		}
+		traceID, spanID := sc.TraceID(), sc.SpanID()
+		for i := range traceID {
+			traceID[i] ^= salt
+		}
+		for i := range spanID {
+			spanID[i] ^= salt
+		}
+		return sc.WithTraceID(traceID).WithSpanID(spanID)
+	}
+
+	out := make([]tracetest.SpanStub, len(spans))
+	for i, s := range spans {
+		s.SpanContext = remap(s.SpanContext)
+		s.Parent = remap(s.Parent)
+		s.StartTime = s.StartTime.Add(offset)
+		s.EndTime = s.EndTime.Add(offset)
+		out[i] = s
+	}
+	return out
+}
+
+func TestWithSnapshotMode(t *testing.T) {
+	first := sampleSpans()
+	second := relabel(first, 72*time.Hour+time.Millisecond, 0x5a)
+
+	var a, b bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&a, first, printer.WithSnapshotMode()))
+	must.NoError(t, printer.PrintSpanTree(&b, second, printer.WithSnapshotMode()))
+
+	must.Eq(t, a.String(), b.String())
+	must.StrNotContains(t, a.String(), "\x1b[")
+	must.StrNotContains(t, a.String(), first[0].SpanContext.TraceID().String())
+	must.StrNotContains(t, a.String(), "Start Time:")
+	must.StrContains(t, a.String(), "Duration:  [<1s]")
+	must.StrContains(t, a.String(), "child-span-3")
+}
+
+func TestWithDurationBuckets(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 250*ms),
+		newSpan("fast", 2, 1, 0, 5*ms),
+	}
+	buckets := []time.Duration{100 * ms, ms, 10 * ms}
+
+	for _, opts := range [][]printer.Option{
+		{printer.WithSnapshotMode(), printer.WithDurationBuckets(buckets)},
+		{printer.WithDurationBuckets(buckets), printer.WithSnapshotMode()},
+	} {
+		var buf bytes.Buffer
+		must.NoError(t, printer.PrintSpanTree(&buf, spans, opts...))
+
+		must.StrContains(t, buf.String(), "Duration:  [<10ms]")
+		must.StrContains(t, buf.String(), "Duration:  [>=100ms]")
+	}
+}
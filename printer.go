@@ -3,16 +3,24 @@ package printer
 import (
 	"fmt"
 	"io"
-	"sort"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// timeFormat is how we display span start/end times.
-// Adjust as needed (e.g., omit date, show only HH:MM:SS).
+// timeFormat is how we display span start/end times unless overridden with
+// WithTimeFormat.
 const timeFormat = "2006-01-02 15:04:05.000 MST"
 
 var (
@@ -38,120 +46,1253 @@ var (
 	errorHighlightStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("196"))
 
-	// childIndent is the indentation string for nested child boxes.
+	// criticalPathStyle encloses the spans on a trace's critical path, with a
+	// border that stands out with and without colors.
+	criticalPathStyle = boxStyle.
+				BorderStyle(lipgloss.ThickBorder()).
+				BorderForeground(lipgloss.Color("208"))
+
+	// matchStyle marks the text matched by WithHighlight.
+	matchStyle = lipgloss.NewStyle().
+			Reverse(true).
+			Bold(true)
+
+	// barStyle colors the bars drawn by the timeline view.
+	barStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("63"))
+
+	// addedStyle marks spans and attributes present only in the current set
+	// of a diff.
+	addedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42"))
+
+	// removedStyle marks spans and attributes present only in the golden set
+	// of a diff.
+	removedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+
+	// changedStyle marks attributes whose value differs between the two sets
+	// of a diff.
+	changedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214"))
+
+	// heatStyles color minimap cells from the shortest spans to the longest.
+	heatStyles = []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("220")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("208")),
+	}
+
+	// rootMarker prefixes root span names when WithRootMarker is set.
+	rootMarker = "◉ ROOT"
+
+	// childIndent indents nested child boxes unless set with WithIndent, and
+	// always indents the lists inside a box.
 	childIndent = "  "
 )
 
+// Printer prints span trees with a configuration resolved once, for
+// printing many sets of spans the same way. A Printer is safe for concurrent
+// use.
+type Printer struct {
+	cfg *Config
+}
+
+// NewPrinter returns a Printer configured by opts.
+func NewPrinter(opts ...Option) *Printer {
+	return &Printer{cfg: newConfig(opts...)}
+}
+
+// Print writes spans to w as PrintSpanTree does, using p's configuration.
+func (p *Printer) Print(w io.Writer, spans []tracetest.SpanStub) error {
+	return printSpanTree(w, writerConfig(p.cfg, w), spans)
+}
+
 // PrintSpanTree organizes spans into a hierarchical tree of parent → children
 // and writes them to w. Each parent’s box encloses its children’s boxes.
-func PrintSpanTree(w io.Writer, spans []tracetest.SpanStub) {
+//
+// Options may be supplied to adjust what is rendered; with no options the
+// output matches the printer's default appearance.
+//
+// Spans that share a SpanID are reported with a warning ahead of the trees,
+// which are then printed as best they can be.
+//
+// It returns the first error encountered writing to w, after which nothing
+// more is written. If a header or footer template fails to parse or execute,
+// nothing is written and its error is returned.
+func PrintSpanTree(w io.Writer, spans []tracetest.SpanStub, opts ...Option) error {
+	return NewPrinter(opts...).Print(w, spans)
+}
+
+// printSpanTree writes the boxed tree of spans to w as configured by cfg.
+func printSpanTree(w io.Writer, cfg *Config, spans []tracetest.SpanStub) error {
+	if cfg.templateErr != nil {
+		return cfg.templateErr
+	}
 	if len(spans) == 0 {
-		return
+		return nil
 	}
 
-	// Build a map of SpanID → SpanStub for quick lookups
-	spanByID := make(map[string]tracetest.SpanStub, len(spans))
-	for _, s := range spans {
-		spanByID[s.SpanContext.SpanID().String()] = s
+	out := &lineWriter{w: w}
+
+	spans = filterSpans(cfg, spans)
+	if len(spans) == 0 {
+		return nil
 	}
+	tree := newSpanTree(cfg, spans)
 
-	// Build a parent → slice of children map
-	childrenMap := make(map[string][]tracetest.SpanStub)
-	for _, s := range spans {
-		if parentID := s.Parent.SpanID().String(); s.Parent.SpanID().IsValid() {
-			childrenMap[parentID] = append(childrenMap[parentID], s)
+	// Render the templates before writing anything, so one that fails
+	// leaves no partial output
+	var header, footer string
+	if cfg.headerTemplate != nil || cfg.footerTemplate != nil {
+		data := newTemplateData(cfg, spans)
+		var err error
+		if header, err = renderTemplate(cfg.headerTemplate, data); err != nil {
+			return err
+		}
+		if footer, err = renderTemplate(cfg.footerTemplate, data); err != nil {
+			return err
+		}
+	}
+
+	// Warn about shared SpanIDs, counting rather than naming them when IDs
+	// are hidden
+	if cfg.HideIDs && len(tree.duplicates) > 0 {
+		out.println(cfg.styles.errorHighlight.Render(fmt.Sprintf("warning: %s encountered, output may be incomplete",
+			plural(len(tree.duplicates), "duplicate SpanID", "duplicate SpanIDs"))))
+	} else {
+		for _, id := range tree.duplicates {
+			out.println(cfg.styles.errorHighlight.Render(fmt.Sprintf("warning: duplicate SpanID %s encountered, output may be incomplete", displayID(cfg, id))))
 		}
 	}
 
-	// Sort children by start time for stable ordering
-	for pid := range childrenMap {
-		sort.Slice(childrenMap[pid], func(i, j int) bool {
-			return childrenMap[pid][i].StartTime.Before(childrenMap[pid][j].StartTime)
-		})
+	if cfg.headerTemplate != nil {
+		out.println(header)
 	}
 
-	// Identify the root spans (i.e., those with no valid parent).
-	var roots []tracetest.SpanStub
-	for _, s := range spans {
-		if !s.Parent.SpanID().IsValid() {
-			roots = append(roots, s)
+	if cfg.Legend {
+		out.println(legendSection(cfg))
+	}
+
+	// Print the resource shared by every span once, up front
+	if cfg.ShowResource {
+		cfg.sharedResource = sharedResource(spans)
+		if section := resourceSection(cfg); section != "" {
+			out.println(section)
+		}
+	}
+
+	if cfg.RelativeTimes {
+		cfg.traceStart = earliestStart(spans)
+	}
+
+	// Pull flagged spans up into their own section ahead of the trees
+	if cfg.FlagPredicate != nil {
+		if section := flagSection(cfg, tree); section != "" {
+			out.println(section)
+		}
+	}
+
+	if cfg.ErrorTimeShare {
+		cfg.errorShares = make(map[string]float64)
+		for _, roots := range tree.traces() {
+			maps.Copy(cfg.errorShares, tree.errorTimeShares(cfg, roots))
+		}
+	}
+
+	if cfg.CriticalPath {
+		cfg.criticalSpans = make(map[string]bool)
+		for _, roots := range tree.traces() {
+			path, _ := tree.criticalPath(roots)
+			for _, s := range path {
+				cfg.criticalSpans[s.SpanContext.SpanID().String()] = true
+			}
+		}
+	}
+
+	if cfg.PageColumns > 1 {
+		printPageColumns(out, cfg, tree)
+	} else {
+		// Recursively build + print each root, one trace at a time
+		traces := tree.traces()
+		for i, roots := range traces {
+			if len(traces) > 1 {
+				out.println(traceHeader(cfg, tree, i, roots))
+			}
+			for _, root := range roots {
+				if out.err != nil {
+					return out.err
+				}
+				out.println(buildSpanBox(cfg, tree, root, nil))
+			}
+
+			for _, line := range traceFooter(cfg, tree, roots) {
+				out.println(line)
+			}
+		}
+	}
+
+	if cfg.footerTemplate != nil {
+		out.println(footer)
+	}
+
+	return out.err
+}
+
+// lineWriter writes lines to w until a write fails, keeping the first
+// error.
+type lineWriter struct {
+	w   io.Writer
+	err error
+}
+
+// println writes s and a newline, unless an earlier write failed.
+func (lw *lineWriter) println(s string) {
+	if lw.err == nil {
+		_, lw.err = fmt.Fprintln(lw.w, s)
+	}
+}
+
+// printPageColumns writes every root tree, distributed across
+// cfg.PageColumns columns, followed by each trace's footer lines.
+func printPageColumns(out *lineWriter, cfg *Config, tree *spanTree) {
+	columns := make([][]string, cfg.PageColumns)
+	heights := make([]int, cfg.PageColumns)
+
+	var footers []string
+	traces := tree.traces()
+	for i, roots := range traces {
+		for j, root := range roots {
+			box := buildSpanBox(cfg, tree, root, nil)
+			if len(traces) > 1 && j == 0 {
+				box = lipgloss.JoinVertical(lipgloss.Left, traceHeader(cfg, tree, i, roots), box)
+			}
+
+			shortest := 0
+			for i, h := range heights {
+				if h < heights[shortest] {
+					shortest = i
+				}
+			}
+			columns[shortest] = append(columns[shortest], box)
+			heights[shortest] += lipgloss.Height(box)
+		}
+		footers = append(footers, traceFooter(cfg, tree, roots)...)
+	}
+
+	var blocks []string
+	for i, column := range columns {
+		if len(column) == 0 {
+			continue
+		}
+		if i > 0 {
+			blocks = append(blocks, " ")
+		}
+		blocks = append(blocks, lipgloss.JoinVertical(lipgloss.Left, column...))
+	}
+	out.println(lipgloss.JoinHorizontal(lipgloss.Top, blocks...))
+
+	for _, line := range footers {
+		out.println(line)
+	}
+}
+
+// traceHeader returns the line introducing the i-th trace, formed by roots,
+// when spans from several traces are printed together.
+func traceHeader(cfg *Config, tree *spanTree, i int, roots []tracetest.SpanStub) string {
+	id := displayID(cfg, roots[0].SpanContext.TraceID().String())
+	if cfg.HideIDs {
+		id = fmt.Sprintf("#%d", i+1)
+	}
+	count := plural(len(tree.descendants(roots)), "span", "spans")
+	return cfg.styles.label.Render(fmt.Sprintf("Trace %s (%s)", id, count))
+}
+
+// traceFooter returns the summary lines printed after a trace's roots, based
+// on which summaries are enabled in cfg.
+func traceFooter(cfg *Config, tree *spanTree, roots []tracetest.SpanStub) []string {
+	var lines []string
+
+	if cfg.Summary {
+		spans := tree.descendants(roots)
+		errors := 0
+		var slowest tracetest.SpanStub
+		var slowestSelf time.Duration
+		for i, s := range spans {
+			if isErrorSpan(cfg, s) {
+				errors++
+			}
+			if self := selfTime(s, tree.childrenOf(s)); i == 0 || self > slowestSelf {
+				slowest, slowestSelf = s, self
+			}
+		}
+		lines = append(lines, cfg.styles.value.Render(fmt.Sprintf("summary: %s, %s, %s total, slowest '%s' (%s self)",
+			plural(len(spans), "span", "spans"),
+			plural(errors, "error", "errors"),
+			formatDuration(cfg, wallClock(spans)),
+			slowest.Name,
+			formatDuration(cfg, slowestSelf),
+		)))
+	}
+
+	if cfg.CriticalPath {
+		_, length := tree.criticalPath(roots)
+		total := wallClock(tree.descendants(roots))
+		lines = append(lines, cfg.styles.value.Render(fmt.Sprintf("critical path: %s (of %s total)", formatDuration(cfg, length), formatDuration(cfg, total))))
+	}
+
+	if cfg.ServiceSummary {
+		var names []string
+		seen := make(map[string]bool)
+		for _, s := range tree.descendants(roots) {
+			if name := serviceName(s); !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		lines = append(lines, cfg.styles.value.Render(fmt.Sprintf("services: %d (%s)", len(names), strings.Join(names, ", "))))
+	}
+
+	if cfg.FanOutSummary {
+		maxFanOut, parents, children := 0, 0, 0
+		for _, s := range tree.descendants(roots) {
+			if n := len(tree.childrenOf(s)); n > 0 {
+				maxFanOut = max(maxFanOut, n)
+				parents++
+				children += n
+			}
+		}
+		avg := 0.0
+		if parents > 0 {
+			avg = float64(children) / float64(parents)
+		}
+		lines = append(lines, cfg.styles.value.Render(fmt.Sprintf("max fan-out: %d, avg fan-out: %.1f", maxFanOut, avg)))
+	}
+
+	if cfg.DepthHistogram {
+		var counts []int
+		var walk func(span tracetest.SpanStub, depth int)
+		walk = func(span tracetest.SpanStub, depth int) {
+			if depth == len(counts) {
+				counts = append(counts, 0)
+			}
+			counts[depth]++
+			for _, child := range tree.childrenOf(span) {
+				walk(child, depth+1)
+			}
 		}
+		for _, root := range roots {
+			walk(root, 0)
+		}
+		levels := make([]string, len(counts))
+		for depth, n := range counts {
+			levels[depth] = fmt.Sprintf("L%d=%d", depth, n)
+		}
+		lines = append(lines, cfg.styles.value.Render("depth histogram: "+strings.Join(levels, " ")))
+	}
+
+	if cfg.EventBookends {
+		var first, last *sdktrace.Event
+		for _, s := range tree.descendants(roots) {
+			for _, event := range s.Events {
+				if first == nil || event.Time.Before(first.Time) {
+					first = &event
+				}
+				if last == nil || event.Time.After(last.Time) {
+					last = &event
+				}
+			}
+		}
+		if first != nil {
+			lines = append(lines, cfg.styles.value.Render(fmt.Sprintf("first event: %q at %s; last: %q at %s",
+				first.Name, formatSpanTime(cfg, first.Time), last.Name, formatSpanTime(cfg, last.Time))))
+		}
+	}
+
+	if cfg.TruncationDetection {
+		for _, reason := range tree.truncationReasons(cfg, roots) {
+			lines = append(lines, cfg.styles.errorHighlight.Render("⚠ trace may be truncated: "+reason))
+		}
+	}
+
+	return lines
+}
+
+// sharedResource returns the resource attributes that every span has with
+// the same value.
+func sharedResource(spans []tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	var shared map[attribute.Key]attribute.Value
+	for i, s := range spans {
+		var attrs []attribute.KeyValue
+		if s.Resource != nil {
+			attrs = s.Resource.Attributes()
+		}
+
+		if i == 0 {
+			shared = make(map[attribute.Key]attribute.Value, len(attrs))
+			for _, attr := range attrs {
+				shared[attr.Key] = attr.Value
+			}
+			continue
+		}
+
+		own := make(map[attribute.Key]attribute.Value, len(attrs))
+		for _, attr := range attrs {
+			own[attr.Key] = attr.Value
+		}
+		for key, val := range shared {
+			if v, ok := own[key]; !ok || v != val {
+				delete(shared, key)
+			}
+		}
+	}
+	return shared
+}
+
+// resourceSection renders a box listing the resource attributes shared by
+// every span, or "" when there are none.
+func resourceSection(cfg *Config) string {
+	if len(cfg.sharedResource) == 0 {
+		return ""
 	}
 
-	// Sort roots by start time for stable ordering
-	sort.Slice(roots, func(i, j int) bool {
-		return roots[i].StartTime.Before(roots[j].StartTime)
+	attrs := make([]attribute.KeyValue, 0, len(cfg.sharedResource))
+	for key, val := range cfg.sharedResource {
+		attrs = append(attrs, attribute.KeyValue{Key: key, Value: val})
+	}
+	slices.SortFunc(attrs, func(a, b attribute.KeyValue) int {
+		return strings.Compare(string(a.Key), string(b.Key))
 	})
 
-	// Recursively build + print each root
-	for _, root := range roots {
-		treeStr := buildSpanBox(root, childrenMap)
-		fmt.Fprintln(w, treeStr)
+	lines := append([]string{cfg.styles.label.Render("Resource:")}, attributeLines(cfg, attrs, childIndent, false)...)
+	return cfg.styles.box.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// resourceLines renders a "Resource:" section listing the span's resource
+// attributes that aren't shared by every span. Nothing is returned when
+// there are none.
+func resourceLines(cfg *Config, span tracetest.SpanStub) []string {
+	if span.Resource == nil {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	for _, attr := range span.Resource.Attributes() {
+		if v, ok := cfg.sharedResource[attr.Key]; !ok || v != attr.Value {
+			attrs = append(attrs, attr)
+		}
+	}
+	if len(attrs) == 0 {
+		return nil
+	}
+	return append([]string{cfg.styles.label.Render("Resource:")}, attributeLines(cfg, attrs, childIndent, false)...)
+}
+
+// flagSection renders a box listing every span matching cfg.FlagPredicate,
+// in tree order, each with the path of span names leading to it. It returns
+// "" when no span matches.
+func flagSection(cfg *Config, tree *spanTree) string {
+	var entries []string
+	var walk func(span tracetest.SpanStub, path []string)
+	walk = func(span tracetest.SpanStub, path []string) {
+		path = append(path, span.Name)
+		if cfg.FlagPredicate(span) {
+			entry := fmt.Sprintf("• %s (%s) at %s", span.Name, formatSpanDuration(cfg, span), strings.Join(path, " › "))
+			entries = append(entries, childIndent+cfg.styles.errorHighlight.Render(entry))
+		}
+		for _, child := range tree.childrenOf(span) {
+			walk(child, slices.Clip(path))
+		}
+	}
+	for _, root := range tree.roots {
+		walk(root, nil)
+	}
+
+	if len(entries) == 0 {
+		return ""
+	}
+
+	lines := append([]string{cfg.styles.label.Render("Flagged spans:")}, entries...)
+	return cfg.styles.box.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// legendSection renders a box explaining what each style in the trees
+// means, drawn in the styles configured by cfg. Entries for the critical
+// path and highlight styles only appear when those features are enabled.
+func legendSection(cfg *Config) string {
+	border := func(color lipgloss.TerminalColor, glyph string) string {
+		return cfg.styles.value.Foreground(color).Render(glyph)
+	}
+	entries := [][2]string{
+		{cfg.styles.label.Render("Label"), "field labels such as \"Span Name:\""},
+		{cfg.styles.value.Render("value"), "field and attribute values"},
+		{cfg.styles.errorHighlight.Render("error"), "error attributes, statuses, and warnings"},
+		{border(cfg.styles.box.GetBorderLeftForeground(), "│"), "span box border"},
+		{border(cfg.styles.errorBorder, "│"), "failed span box border"},
+	}
+	if cfg.CriticalPath {
+		entries = append(entries, [2]string{border(cfg.styles.criticalPath.GetBorderLeftForeground(), "┃"), "critical path span box border"})
+	}
+	if cfg.highlight != nil {
+		entries = append(entries, [2]string{cfg.styles.match.Render("match"), fmt.Sprintf("text matching %q", cfg.Highlight)})
+	}
+
+	width := 0
+	for _, e := range entries {
+		width = max(width, lipgloss.Width(e[0]))
 	}
+	lines := []string{cfg.styles.label.Render("Legend:")}
+	for _, e := range entries {
+		pad := strings.Repeat(" ", width-lipgloss.Width(e[0]))
+		lines = append(lines, childIndent+e[0]+pad+"  "+e[1])
+	}
+	return cfg.styles.box.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// serviceName returns the service.name resource attribute of span, or
+// "(unknown)" when it has none.
+func serviceName(span tracetest.SpanStub) string {
+	if span.Resource != nil {
+		if v, ok := span.Resource.Set().Value(semconv.ServiceNameKey); ok && v.AsString() != "" {
+			return v.AsString()
+		}
+	}
+	return "(unknown)"
 }
 
 // buildSpanBox returns a single Lip Gloss-rendered string containing:
 //   - The current span’s details
 //   - All of its children’s boxes (recursively)
-func buildSpanBox(span tracetest.SpanStub, childrenMap map[string][]tracetest.SpanStub) string {
+//
+// ancestors holds the SpanIDs of the spans enclosing span, so its size is
+// span's depth; it is nil for a root. A child already among them closes a
+// cycle, which is marked rather than followed.
+func buildSpanBox(cfg *Config, tree *spanTree, span tracetest.SpanStub, ancestors map[string]bool) string {
+	depth := len(ancestors)
+
 	// 1) Build lines for this span
 	var lines []string
 
-	lines = append(lines, joinLabelValue("Span Name:", span.Name))
-	lines = append(lines, joinLabelValue("TraceID:", span.SpanContext.TraceID().String()))
-	lines = append(lines, joinLabelValue("SpanID:", span.SpanContext.SpanID().String()))
+	name := span.Name + indicatorGlyphs(cfg, span)
+	if cfg.RootMarker && !span.Parent.SpanID().IsValid() {
+		name = rootMarker + " " + name
+	}
+	if cfg.ShortHashLabels {
+		name += " [" + ShortLabel(span.SpanContext.SpanID()) + "]"
+	}
+	if cfg.SiblingRank && span.Parent.SpanID().IsValid() {
+		name += siblingRank(span, tree.children[span.Parent.SpanID().String()])
+	}
+	if cfg.OverlapMarker && span.Parent.SpanID().IsValid() {
+		name += overlapMarker(span, tree.children[span.Parent.SpanID().String()])
+	}
+	if cfg.highlight != nil {
+		lines = append(lines, cfg.styles.joinLabelStyled("Span Name:", lipgloss.NewStyle(), highlightMatches(cfg, name, cfg.styles.value)))
+	} else {
+		lines = append(lines, cfg.styles.joinLabelValue("Span Name:", name))
+	}
+
+	// Note when the span's parent wasn't captured
+	if tree.isOrphan(span) {
+		note := "(orphaned: parent not captured)"
+		if !cfg.HideIDs {
+			note = fmt.Sprintf("(orphaned: parent %s not captured)", displayID(cfg, span.Parent.SpanID().String()))
+		}
+		lines = append(lines, cfg.styles.value.Render(note))
+	}
 
-	// Include parent ID if valid
-	if span.Parent.SpanID().IsValid() {
-		lines = append(lines, joinLabelValue("ParentSpan:", span.Parent.SpanID().String()))
+	if !cfg.HideIDs {
+		lines = append(lines, cfg.styles.joinLabelValue("TraceID:", linkID(cfg, TraceIDKind, span.SpanContext.TraceID().String())))
+		lines = append(lines, cfg.styles.joinLabelValue("SpanID:", linkID(cfg, SpanIDKind, span.SpanContext.SpanID().String())))
+
+		// Include parent ID if valid
+		if span.Parent.SpanID().IsValid() {
+			lines = append(lines, cfg.styles.joinLabelValue("ParentSpan:", linkID(cfg, SpanIDKind, span.Parent.SpanID().String())))
+		}
 	}
 
 	// Format times to avoid the verbose 'm=+...'
-	lines = append(lines, joinLabelValue("Start Time:", formatTime(span.StartTime)))
-	lines = append(lines, joinLabelValue("End Time:", formatTime(span.EndTime)))
+	if !cfg.HideTimes {
+		start := formatSpanTime(cfg, span.StartTime)
+		if epoch, ok := epochValue(cfg.EpochOffset, span.StartTime); ok && !span.StartTime.IsZero() {
+			start += fmt.Sprintf(" (epoch: %d)", epoch)
+		}
+		lines = append(lines, cfg.styles.joinLabelValue("Start Time:", start))
+		lines = append(lines, cfg.styles.joinLabelValue("End Time:", formatSpanTime(cfg, span.EndTime)))
+	}
 
-	duration := span.EndTime.Sub(span.StartTime)
-	lines = append(lines, joinLabelValue("Duration:", duration))
+	duration, known := spanDuration(span)
+	switch durationValue := formatSpanDuration(cfg, span); {
+	case durationValue == invalidDuration:
+		lines = append(lines, cfg.styles.joinLabelStyled("Duration:", cfg.styles.errorHighlight, "("+invalidDuration+")"))
+	case !known:
+		// Without both endpoints there's no duration to show
+	default:
+		if share, ok := cfg.errorShares[span.SpanContext.SpanID().String()]; ok {
+			durationValue += fmt.Sprintf(" (%.0f%% of error time)", share*100)
+		}
+		lines = append(lines, cfg.styles.joinLabelValue("Duration:", durationValue))
 
-	// 2) Attributes
-	lines = append(lines, labelStyle.Render("Attributes:"))
-	for _, attr := range span.Attributes {
-		val := attr.Value.AsInterface()
+		// Time not covered by any child
+		if cfg.SelfTime {
+			self := selfTime(span, tree.children[span.SpanContext.SpanID().String()])
+			lines = append(lines, cfg.styles.joinLabelValue("Self Time:", formatDuration(cfg, self)))
+		}
+	}
 
-		// If this attribute is an error-related key, highlight it
-		attrStyle := valueStyle
-		if isErrorAttribute(string(attr.Key), val) {
-			attrStyle = errorHighlightStyle
+	// Status, unless the span is Unset with nothing to say
+	if span.Status.Code != codes.Unset || span.Status.Description != "" {
+		lines = append(lines, statusLine(cfg, span.Status))
+	}
+
+	// Kind, unless it says nothing beyond the default
+	if kind := spanKindName(span.SpanKind); kind != "" {
+		lines = append(lines, cfg.styles.joinLabelValue("Kind:", kind))
+	}
+
+	// Warn when the SDK had to drop data to stay within its limits
+	if dropped := droppedLine(span); dropped != "" {
+		lines = append(lines, cfg.styles.errorHighlight.Render(dropped))
+	}
+
+	// Flag spans that ended while a child was still running. A span that
+	// hasn't ended yet can't have ended early.
+	if cfg.PrematureCloseMarker && !span.EndTime.IsZero() {
+		var latest *tracetest.SpanStub
+		for _, child := range tree.children[span.SpanContext.SpanID().String()] {
+			if child.EndTime.After(span.EndTime) && (latest == nil || child.EndTime.After(latest.EndTime)) {
+				latest = &child
+			}
 		}
+		if latest != nil {
+			lines = append(lines, cfg.styles.errorHighlight.Render(fmt.Sprintf("⚠ ended before child '%s'", latest.Name)))
+		}
+	}
+
+	// Share of the span's time not spent waiting on children
+	if cfg.Efficiency && duration > 0 {
+		self := selfTime(span, tree.children[span.SpanContext.SpanID().String()])
+		lines = append(lines, cfg.styles.joinLabelValue("Efficiency:", fmt.Sprintf("%.0f%%", float64(self)/float64(duration)*100)))
+	}
+
+	// Resource attributes not shared by every span
+	if cfg.ShowResource {
+		lines = append(lines, resourceLines(cfg, span)...)
+	}
+
+	// Instrumentation scope, if requested and known
+	if cfg.ShowScope {
+		lines = append(lines, scopeLines(cfg, span)...)
+	}
 
-		bullet := fmt.Sprintf("• %s = %v", attr.Key, val)
-		lines = append(lines, childIndent+attrStyle.Render(bullet))
+	// Surface the recorded exception of failed spans
+	if cfg.ExceptionDetails && span.Status.Code == codes.Error {
+		lines = append(lines, exceptionLines(cfg, span)...)
 	}
 
-	// 3) Recursively build child boxes
-	for _, child := range childrenMap[span.SpanContext.SpanID().String()] {
-		childBox := buildSpanBox(child, childrenMap)
-		// Indent child content so it appears nested
-		childBoxIndented := indentAllLines(childBox, childIndent)
-		lines = append(lines, childBoxIndented)
+	// 2) Attributes, summarized past the attribute depth limit
+	lines = append(lines, cfg.styles.label.Render("Attributes:"))
+	attrs := sortedAttributes(cfg, span.Attributes)
+	if len(cfg.AttributeFilter) > 0 {
+		attrs = filterAttributes(span.Attributes, cfg.AttributeFilter)
+	}
+	if cfg.AttributeDepthLimit > 0 && depth > cfg.AttributeDepthLimit && len(attrs) > 0 {
+		lines = append(lines, childIndent+cfg.styles.value.Render(fmt.Sprintf("(%d attributes)", len(attrs))))
+		attrs = nil
+	}
+	lines = append(lines, attributeLines(cfg, attrs, childIndent, false)...)
+
+	// Events, in time order
+	lines = append(lines, eventLines(cfg, span)...)
+
+	// Links to other spans, which aren't part of the tree
+	lines = append(lines, linkLines(cfg, span)...)
+
+	// Optionally sketch the children's timing within this span
+	if cfg.ChildWaterfall {
+		lines = append(lines, childWaterfall(cfg, span, tree.children[span.SpanContext.SpanID().String()])...)
+	}
+
+	// 3) Recursively build child boxes, unless nesting any deeper would make
+	// the output unmanageable
+	children := tree.children[span.SpanContext.SpanID().String()]
+	if cfg.MaxDepth > 0 && depth >= cfg.MaxDepth && len(children) > 0 {
+		note := "… " + collapsedSummary(cfg, collectDescendants(span, tree.children)) + " hidden (max depth reached)"
+		lines = append(lines, childIndent+cfg.styles.value.Render(note))
+		children = nil
+	}
+	if n := cfg.fastHidden[span.SpanContext.SpanID().String()]; n > 0 {
+		lines = append(lines, childIndent+cfg.styles.value.Render("("+plural(n, "fast span", "fast spans")+" hidden)"))
+	}
+	if cfg.MaxNesting > 0 && depth+1 >= cfg.MaxNesting && len(children) > 0 {
+		note := "⚠ nesting limit reached: " + collapsedSummary(cfg, collectDescendants(span, tree.children)) + " not shown"
+		lines = append(lines, childIndent+cfg.styles.errorHighlight.Render(note))
+		children = nil
+	}
+	if len(children) > 0 {
+		if ancestors == nil {
+			ancestors = make(map[string]bool)
+		}
+		id := span.SpanContext.SpanID().String()
+		ancestors[id] = true
+		for _, child := range children {
+			if childID := child.SpanContext.SpanID().String(); childID == id || ancestors[childID] {
+				note := fmt.Sprintf("↺ %s (cycle detected)", child.Name)
+				lines = append(lines, childIndent+cfg.styles.errorHighlight.Render(note))
+				continue
+			}
+			childBox := buildSpanBox(cfg, tree, child, ancestors)
+			// Indent child content so it appears nested
+			childBoxIndented := indentAllLines(childBox, cfg.Indent)
+			lines = append(lines, childBoxIndented)
+		}
+		delete(ancestors, id)
 	}
 
 	// 4) Combine all lines vertically
 	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
 
-	// 5) Wrap in a single box
-	return boxStyle.Render(content)
+	// 5) Wrap in a single box, set apart when on the critical path and
+	// bordered in the error color when the span failed
+	style := cfg.styles.box
+	if cfg.criticalSpans[span.SpanContext.SpanID().String()] {
+		style = cfg.styles.criticalPath
+	}
+	if isErrorSpan(cfg, span) {
+		style = style.BorderForeground(cfg.styles.errorBorder)
+	}
+	if cfg.MaxWidth > 0 {
+		// Each enclosing box takes its frame and the indent of its
+		// children away from the width left for this one. The frame is
+		// measured on an empty box because the style's getters don't
+		// count a border that is only implied by its border style.
+		frame := lipgloss.Width(style.Render(""))
+		available := cfg.MaxWidth - depth*(frame+lipgloss.Width(cfg.Indent))
+		if lipgloss.Width(content)+frame > available {
+			style = style.Width(max(available-frame+style.GetHorizontalPadding(), 1))
+		}
+	}
+	return style.Render(content)
+}
+
+// statusLine renders a "Status:" line with the status code and, when
+// present, its description. Error statuses are highlighted.
+func statusLine(cfg *Config, status sdktrace.Status) string {
+	text := status.Code.String()
+	if status.Description != "" {
+		text += ": " + status.Description
+	}
+
+	style := cfg.styles.value
+	if status.Code == codes.Error {
+		style = cfg.styles.errorHighlight
+	}
+	return cfg.styles.joinLabelStyled("Status:", style, text)
+}
+
+// spanKindName returns the capitalized name of kind, or "" for an internal
+// or unspecified kind.
+func spanKindName(kind trace.SpanKind) string {
+	switch kind {
+	case trace.SpanKindServer:
+		return "Server"
+	case trace.SpanKindClient:
+		return "Client"
+	case trace.SpanKindProducer:
+		return "Producer"
+	case trace.SpanKindConsumer:
+		return "Consumer"
+	default:
+		return ""
+	}
+}
+
+// indicatorGlyphs returns the glyphs appended to a span's name when it has
+// events or links, or "" when indicator glyphs are disabled.
+func indicatorGlyphs(cfg *Config, span tracetest.SpanStub) string {
+	if !cfg.IndicatorGlyphs {
+		return ""
+	}
+
+	var glyphs string
+	if len(span.Events) > 0 {
+		glyphs += " " + cfg.EventGlyph
+	}
+	if len(span.Links) > 0 {
+		glyphs += " " + cfg.LinkGlyph
+	}
+	return glyphs
+}
+
+// siblingRank returns a note ranking span's duration among siblings, e.g.
+// " (slowest of 3)" or " (2nd of 3 by duration)", or "" for an only child.
+// Siblings with equal durations share a rank.
+func siblingRank(span tracetest.SpanStub, siblings []tracetest.SpanStub) string {
+	if len(siblings) < 2 {
+		return ""
+	}
+
+	duration, _ := spanDuration(span)
+	rank := 1
+	for _, s := range siblings {
+		if d, _ := spanDuration(s); d > duration {
+			rank++
+		}
+	}
+
+	if rank == 1 {
+		return fmt.Sprintf(" (slowest of %d)", len(siblings))
+	}
+	return fmt.Sprintf(" (%s of %d by duration)", ordinal(rank), len(siblings))
+}
+
+// overlapMarker returns " (concurrent with N siblings)" when span's time
+// range overlaps N of its siblings, " (serial)" when it overlaps none, or ""
+// for an only child. Ranges that merely touch don't overlap.
+func overlapMarker(span tracetest.SpanStub, siblings []tracetest.SpanStub) string {
+	if len(siblings) < 2 {
+		return ""
+	}
+
+	id := span.SpanContext.SpanID()
+	overlapping := 0
+	for _, s := range siblings {
+		if s.SpanContext.SpanID() == id {
+			continue
+		}
+		if s.StartTime.Before(span.EndTime) && span.StartTime.Before(s.EndTime) {
+			overlapping++
+		}
+	}
+
+	if overlapping == 0 {
+		return " (serial)"
+	}
+	return fmt.Sprintf(" (concurrent with %s)", plural(overlapping, "sibling", "siblings"))
+}
+
+// ordinal returns n with its English ordinal suffix, e.g. "2nd" or "11th".
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
 }
 
-// joinLabelValue is a helper that renders "Label: Value" with distinct
-// styling for each portion.
-func joinLabelValue(label string, val interface{}) string {
-	return labelStyle.Render(label) + "  " + valueStyle.Render(fmt.Sprintf("%v", val))
+// exceptionStackLines is how many stacktrace lines exceptionLines shows.
+const exceptionStackLines = 5
+
+// exceptionLines renders an "Error:" block from span's first "exception"
+// event: the exception type and message, followed by the start of its
+// stacktrace. Nothing is returned when the span recorded no exception.
+func exceptionLines(cfg *Config, span tracetest.SpanStub) []string {
+	for _, event := range span.Events {
+		if event.Name != semconv.ExceptionEventName {
+			continue
+		}
+
+		var typ, message, stacktrace string
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case semconv.ExceptionTypeKey:
+				typ = attr.Value.Emit()
+			case semconv.ExceptionMessageKey:
+				message = attr.Value.Emit()
+			case semconv.ExceptionStacktraceKey:
+				stacktrace = attr.Value.Emit()
+			}
+		}
+
+		summary := message
+		if typ != "" {
+			summary = typ + ": " + message
+		}
+		lines := []string{cfg.styles.joinLabelStyled("Error:", cfg.styles.errorHighlight, summary)}
+		if stacktrace != "" {
+			stack := limitLines(strings.TrimRight(stacktrace, "\n"), exceptionStackLines, "")
+			lines = append(lines, indentAllLines(cfg.styles.errorHighlight.Render(stack), childIndent))
+		}
+		return lines
+	}
+	return nil
 }
 
-// formatTime returns a more concise string for the given time.
-func formatTime(t time.Time) string {
-	return t.Format(timeFormat)
+// attributeLines renders one bullet per attribute, in the given order, each
+// indented by indent. Error-related attributes are highlighted, as is every
+// attribute when highlight is set.
+func attributeLines(cfg *Config, attrs []attribute.KeyValue, indent string, highlight bool) []string {
+	lines := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		val := attr.Value.AsInterface()
+
+		// If this attribute is an error-related key, highlight it
+		attrStyle := cfg.styles.value
+		if highlight || detectErrorAttribute(cfg, string(attr.Key), val) {
+			attrStyle = cfg.styles.errorHighlight
+		}
+
+		prefix := fmt.Sprintf("• %s = ", attr.Key)
+		bullet := prefix + truncateValue(formatAttributeValue(cfg, attr), cfg.MaxAttributeValueLength)
+		if cfg.MaxAttributeValueLines > 0 {
+			bullet = limitLines(bullet, cfg.MaxAttributeValueLines, strings.Repeat(" ", lipgloss.Width(prefix)))
+		}
+		rendered := attrStyle.Render(bullet)
+		if cfg.highlight != nil {
+			rendered = attrStyle.Render(prefix) + highlightMatches(cfg, strings.TrimPrefix(bullet, prefix), attrStyle)
+		}
+		lines = append(lines, indentAllLines(rendered, indent))
+	}
+	return lines
+}
+
+// highlightMatches renders s in style, except for the text matched by
+// cfg.highlight, which is rendered in the match style instead.
+func highlightMatches(cfg *Config, s string, style lipgloss.Style) string {
+	if cfg.highlight == nil {
+		return style.Render(s)
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range cfg.highlight.FindAllStringIndex(s, -1) {
+		if m[0] > last {
+			b.WriteString(style.Render(s[last:m[0]]))
+		}
+		b.WriteString(cfg.styles.match.Render(s[m[0]:m[1]]))
+		last = m[1]
+	}
+	if last < len(s) {
+		b.WriteString(style.Render(s[last:]))
+	}
+	return b.String()
+}
+
+// eventLines renders an "Events:" section listing the span's events in time
+// order, each followed by its attributes. Exception events are highlighted.
+// With cfg.MaxEvents set, only the earliest events are listed, followed by a
+// count of the rest. Nothing is returned for a span without events.
+func eventLines(cfg *Config, span tracetest.SpanStub) []string {
+	if len(span.Events) == 0 {
+		return nil
+	}
+
+	events := slices.Clone(span.Events)
+	slices.SortStableFunc(events, func(a, b sdktrace.Event) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	lines := []string{cfg.styles.label.Render("Events:")}
+	shown := events
+	if cfg.MaxEvents > 0 && len(events) > cfg.MaxEvents {
+		shown = events[:cfg.MaxEvents]
+	}
+	for _, event := range shown {
+		exception := event.Name == semconv.ExceptionEventName
+		style := cfg.styles.value
+		if exception {
+			style = cfg.styles.errorHighlight
+		}
+
+		bullet := "• " + event.Name
+		if !cfg.HideTimes {
+			bullet += " at " + formatSpanTime(cfg, event.Time)
+		}
+		lines = append(lines, childIndent+style.Render(bullet))
+		lines = append(lines, attributeLines(cfg, sortedAttributes(cfg, event.Attributes), childIndent+childIndent, exception)...)
+	}
+	if more := len(events) - len(shown); more > 0 {
+		lines = append(lines, childIndent+cfg.styles.value.Render("… "+plural(more, "more event", "more events")))
+	}
+	return lines
+}
+
+// linkLines renders a "Links:" section listing the trace and span ID of each
+// span the span links to, each followed by the link's attributes. Nothing is
+// returned for a span without links.
+func linkLines(cfg *Config, span tracetest.SpanStub) []string {
+	if len(span.Links) == 0 {
+		return nil
+	}
+
+	lines := []string{cfg.styles.label.Render("Links:")}
+	for _, link := range span.Links {
+		bullet := "• linked span"
+		if !cfg.HideIDs {
+			bullet = fmt.Sprintf("• TraceID: %s SpanID: %s",
+				linkID(cfg, TraceIDKind, link.SpanContext.TraceID().String()),
+				linkID(cfg, SpanIDKind, link.SpanContext.SpanID().String()))
+		}
+		lines = append(lines, childIndent+cfg.styles.value.Render(bullet))
+		lines = append(lines, attributeLines(cfg, sortedAttributes(cfg, link.Attributes), childIndent+childIndent, false)...)
+	}
+	return lines
+}
+
+// scopeLines renders the span's instrumentation scope name and version, plus
+// the schema URL when one is set. Nothing is returned for an unnamed scope.
+func scopeLines(cfg *Config, span tracetest.SpanStub) []string {
+	scope := span.InstrumentationScope
+	if scope.Name == "" {
+		// Older fixtures may only populate the deprecated field.
+		scope = span.InstrumentationLibrary
+	}
+	if scope.Name == "" {
+		return nil
+	}
+
+	name := scope.Name
+	if scope.Version != "" {
+		name += " " + scope.Version
+	}
+
+	lines := []string{cfg.styles.joinLabelValue("Scope:", name)}
+	if scope.SchemaURL != "" {
+		lines = append(lines, cfg.styles.joinLabelValue("Schema URL:", scope.SchemaURL))
+	}
+	return lines
+}
+
+// formatDuration renders d with cfg.DurationFormat, mapping it to its coarse
+// bucket label instead when duration buckets are configured.
+func formatDuration(cfg *Config, d time.Duration) string {
+	if cfg.DurationBuckets != nil {
+		return durationBucket(cfg.DurationBuckets, d)
+	}
+	return cfg.DurationFormat(d)
+}
+
+// invalidDuration describes the duration of a span that ends before it
+// starts.
+const invalidDuration = "invalid: end before start"
+
+// formatSpanDuration renders span's duration as formatDuration does, or
+// explains why it has none: "unfinished" when it hasn't ended, "unknown"
+// when its start is unset, and invalidDuration when it ends before it
+// starts.
+func formatSpanDuration(cfg *Config, span tracetest.SpanStub) string {
+	switch {
+	case span.EndTime.IsZero():
+		return "unfinished"
+	case span.StartTime.IsZero():
+		return "unknown"
+	case span.EndTime.Before(span.StartTime):
+		return invalidDuration
+	}
+	return formatDuration(cfg, span.EndTime.Sub(span.StartTime))
+}
+
+// humanDuration renders d in the largest unit that keeps it at or above one,
+// rounded to three significant figures, e.g. "1.50s", "12.3ms", or "850µs".
+// Durations of a minute or more are rounded to the second instead, and those
+// under a microsecond are shown in whole nanoseconds.
+func humanDuration(d time.Duration) string {
+	switch {
+	case d == math.MinInt64:
+		// Can't be negated, but is far beyond anything worth rounding
+		return d.String()
+	case d < 0:
+		return "-" + humanDuration(-d)
+	case d == 0:
+		return "0s"
+	case d >= time.Minute:
+		return d.Round(time.Second).String()
+	case d < time.Microsecond:
+		return fmt.Sprintf("%dns", int64(d))
+	}
+
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{time.Microsecond, "µs"},
+		{time.Millisecond, "ms"},
+		{time.Second, "s"},
+	}
+	i := len(units) - 1
+	for d < units[i].size {
+		i--
+	}
+
+	v := float64(d) / float64(units[i].size)
+	decimals := 2
+	if math.Round(v*100) >= 1000 {
+		decimals = 1
+	}
+	if math.Round(v*10) >= 1000 {
+		decimals = 0
+	}
+	// Rounding can carry into the next unit, as with 999.7ms
+	if math.Round(v) >= 1000 && i < len(units)-1 {
+		return humanDuration(time.Duration(math.Round(v)) * units[i].size)
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64) + units[i].suffix
+}
+
+// durationBucket returns the label of the first bucket d falls under, e.g.
+// "[<10ms]", or "[>=X]" when d is at least the largest bound.
+func durationBucket(buckets []time.Duration, d time.Duration) string {
+	if len(buckets) == 0 {
+		return "[?]"
+	}
+	for _, bound := range buckets {
+		if d < bound {
+			return fmt.Sprintf("[<%v]", bound)
+		}
+	}
+	return fmt.Sprintf("[>=%v]", buckets[len(buckets)-1])
+}
+
+// formatTime returns a more concise string for the given time, using the
+// layout set by WithTimeFormat, or "(unset)" for the zero time.
+func formatTime(cfg *Config, t time.Time) string {
+	if t.IsZero() {
+		return "(unset)"
+	}
+	return t.Format(cfg.TimeFormat)
+}
+
+// formatSpanTime formats a span's start or end time, or the time of one of
+// its events, as an offset from the trace start when cfg.RelativeTimes is
+// set and the trace start is known.
+func formatSpanTime(cfg *Config, t time.Time) string {
+	if !cfg.RelativeTimes || t.IsZero() || cfg.traceStart.IsZero() {
+		return formatTime(cfg, t)
+	}
+	offset := t.Sub(cfg.traceStart)
+	switch {
+	case offset == 0:
+		return "+0ms"
+	case offset > 0:
+		return "+" + formatDuration(cfg, offset)
+	default:
+		return formatDuration(cfg, offset)
+	}
+}
+
+// epochValue returns t since the Unix epoch in unit, or false when unit is
+// not a known EpochUnit.
+func epochValue(unit EpochUnit, t time.Time) (int64, bool) {
+	switch unit {
+	case EpochMillis:
+		return t.UnixMilli(), true
+	case EpochMicros:
+		return t.UnixMicro(), true
+	case EpochNanos:
+		return t.UnixNano(), true
+	default:
+		return 0, false
+	}
+}
+
+// collectDescendants returns every span below span. It walks an explicit
+// stack rather than recursing, so arbitrarily deep chains are safe, and
+// visits each span once, so cycles are too.
+func collectDescendants(span tracetest.SpanStub, childrenMap map[string][]tracetest.SpanStub) []tracetest.SpanStub {
+	var out []tracetest.SpanStub
+	seen := map[string]bool{span.SpanContext.SpanID().String(): true}
+	stack := []tracetest.SpanStub{span}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, child := range childrenMap[current.SpanContext.SpanID().String()] {
+			if id := child.SpanContext.SpanID().String(); !seen[id] {
+				seen[id] = true
+				out = append(out, child)
+				stack = append(stack, child)
+			}
+		}
+	}
+	return out
+}
+
+// collapsedSummary describes spans hidden behind a collapse placeholder, e.g.
+// "(12 spans, 340ms, 1 error)", giving their count, the wall-clock time they
+// cover, and how many failed. Every collapse placeholder uses it so they all
+// read the same.
+func collapsedSummary(cfg *Config, spans []tracetest.SpanStub) string {
+	errors := 0
+	for _, s := range spans {
+		if isErrorSpan(cfg, s) {
+			errors++
+		}
+	}
+	return fmt.Sprintf("(%s, %s, %s)",
+		plural(len(spans), "span", "spans"),
+		formatDuration(cfg, wallClock(spans)),
+		plural(errors, "error", "errors"),
+	)
+}
+
+// droppedLine summarizes the attributes, events, and links the SDK dropped
+// from span, or returns "" when nothing was dropped.
+func droppedLine(span tracetest.SpanStub) string {
+	var parts []string
+	if span.DroppedAttributes > 0 {
+		parts = append(parts, plural(span.DroppedAttributes, "attribute", "attributes"))
+	}
+	if span.DroppedEvents > 0 {
+		parts = append(parts, plural(span.DroppedEvents, "event", "events"))
+	}
+	if span.DroppedLinks > 0 {
+		parts = append(parts, plural(span.DroppedLinks, "link", "links"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Dropped: " + strings.Join(parts, ", ")
+}
+
+// plural returns n followed by singular or plural as appropriate.
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// truncateValue keeps the first n runes of s, noting how many were cut. A
+// non-positive n keeps all of s.
+func truncateValue(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return fmt.Sprintf("%s… (+%d chars)", string(runes[:n]), len(runes)-n)
+}
+
+// limitLines keeps at most n lines of the multi-line string s, noting how
+// many were omitted. Continuation lines are prefixed with indent so they line
+// up under the first line's value column.
+func limitLines(s string, n int, indent string) string {
+	parts := strings.Split(s, "\n")
+	if len(parts) == 1 {
+		return s
+	}
+
+	kept := parts
+	if len(parts) > n {
+		kept = parts[:n]
+	}
+	for i := 1; i < len(kept); i++ {
+		kept[i] = indent + kept[i]
+	}
+	if omitted := len(parts) - len(kept); omitted > 0 {
+		kept = append(kept, fmt.Sprintf("%s… (%d more lines)", indent, omitted))
+	}
+	return strings.Join(kept, "\n")
 }
 
 // indentAllLines applies an indent prefix to each line in a multi-line string.
@@ -163,6 +1304,29 @@ func indentAllLines(s, indent string) string {
 	return strings.Join(out, "\n")
 }
 
+// isErrorSpan reports whether span failed: either its status code is Error
+// or one of its attributes looks error-related.
+func isErrorSpan(cfg *Config, span tracetest.SpanStub) bool {
+	if span.Status.Code == codes.Error {
+		return true
+	}
+	for _, attr := range span.Attributes {
+		if detectErrorAttribute(cfg, string(attr.Key), attr.Value.AsInterface()) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectErrorAttribute reports whether an attribute is error-related, using
+// cfg.ErrorDetector when one is set and the built-in heuristic otherwise.
+func detectErrorAttribute(cfg *Config, key string, val interface{}) bool {
+	if cfg.ErrorDetector != nil {
+		return cfg.ErrorDetector(key, val)
+	}
+	return isErrorAttribute(key, val)
+}
+
 // isErrorAttribute is a simple helper to check if an attribute might be error-related.
 // Customize this logic to suit your system’s notion of “error” or “warning” attributes.
 func isErrorAttribute(key string, val interface{}) bool {
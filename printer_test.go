@@ -2,18 +2,32 @@ package printer_test
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/shoenig/test/must"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
 
 	printer "github.com/picatz/otel-tracetest-printer"
 )
 
-func TestPrintSpanTree(t *testing.T) {
+// sampleSpans returns a small four-span trace: a root with two children, the
+// second of which has its own child and carries an error attribute.
+func sampleSpans() []tracetest.SpanStub {
 	rootSpan := tracetest.SpanStub{
 		Name: "root-span",
 		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
@@ -74,10 +88,42 @@ func TestPrintSpanTree(t *testing.T) {
 		},
 	}
 
-	spans := []tracetest.SpanStub{rootSpan, childSpan1, childSpan2, childSpan3}
+	return []tracetest.SpanStub{rootSpan, childSpan1, childSpan2, childSpan3}
+}
+
+// baseTime anchors the spans built by newSpan.
+var baseTime = time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// newSpan builds a span in a fixed trace with the given single-byte span and
+// parent IDs (0 meaning no parent), running from start to end relative to
+// baseTime.
+func newSpan(name string, id, parent byte, start, end time.Duration) tracetest.SpanStub {
+	traceID := trace.TraceID{0xaa, 0xbb, 0xcc, 0xdd}
+	span := tracetest.SpanStub{
+		Name: name,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{0, 0, 0, 0, 0, 0, 0, id},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		StartTime: baseTime.Add(start),
+		EndTime:   baseTime.Add(end),
+	}
+	if parent != 0 {
+		span.Parent = trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{0, 0, 0, 0, 0, 0, 0, parent},
+			TraceFlags: trace.FlagsSampled,
+		})
+	}
+	return span
+}
+
+func TestPrintSpanTree(t *testing.T) {
+	spans := sampleSpans()
 
 	var buf bytes.Buffer
-	printer.PrintSpanTree(&buf, spans)
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
 	output := buf.String()
 
 	must.StrContains(t, output, "root-span", must.Sprint("Expected 'root-span' in output"))
@@ -90,3 +136,808 @@ func TestPrintSpanTree(t *testing.T) {
 
 	t.Logf("\n%s\n", output)
 }
+
+func TestPrintSpanTree_Scope(t *testing.T) {
+	span := tracetest.SpanStub{
+		Name: "scoped-span",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: [16]byte{1},
+			SpanID:  [8]byte{1},
+		}),
+		StartTime: time.Now().Add(-time.Second),
+		EndTime:   time.Now(),
+		InstrumentationScope: instrumentation.Scope{
+			Name:      "my-instrumentation",
+			Version:   "1.2.3",
+			SchemaURL: "https://opentelemetry.io/schemas/1.26.0",
+		},
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}))
+	must.StrNotContains(t, buf.String(), "my-instrumentation")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithShowScope(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Scope:")
+	must.StrContains(t, output, "my-instrumentation 1.2.3")
+	must.StrContains(t, output, "Schema URL:")
+	must.StrContains(t, output, "https://opentelemetry.io/schemas/1.26.0")
+
+	span.InstrumentationScope.SchemaURL = ""
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithShowScope(true)))
+	must.StrNotContains(t, buf.String(), "Schema URL:")
+
+	// Spans from older SDKs only fill in the deprecated field
+	span.InstrumentationLibrary, span.InstrumentationScope = span.InstrumentationScope, instrumentation.Scope{}
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithShowScope(true)))
+	must.StrContains(t, buf.String(), "my-instrumentation 1.2.3")
+
+	span.InstrumentationLibrary = instrumentation.Scope{Version: "1.2.3"}
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithShowScope(true)))
+	must.StrNotContains(t, buf.String(), "Scope:")
+}
+
+func TestPrintSpanTree_MaxAttributeValueLines(t *testing.T) {
+	span := newSpan("panicky", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.String("stack", "line1\nline2\nline3\nline4\nline5"),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithMaxAttributeValueLines(2)))
+	output := buf.String()
+
+	must.StrContains(t, output, "• stack = line1")
+	must.StrContains(t, output, "            line2")
+	must.StrNotContains(t, output, "line3")
+	must.StrContains(t, output, "… (3 more lines)")
+}
+
+func TestPrintSpanTree_MaxAttributeValueLength(t *testing.T) {
+	span := newSpan("query", 1, 0, 0, time.Second)
+	span.Attributes = []attribute.KeyValue{
+		attribute.String("db.statement", strings.Repeat("é", 500)),
+		attribute.String("db.system", "postgresql"),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithMaxAttributeValueLength(20)))
+	output := buf.String()
+
+	must.StrContains(t, output, "• db.statement = "+strings.Repeat("é", 20)+"… (+480 chars)")
+	must.StrNotContains(t, output, strings.Repeat("é", 21))
+	must.StrContains(t, output, "• db.system = postgresql ")
+}
+
+func TestPrintSpanTree_IndicatorGlyphs(t *testing.T) {
+	spans := sampleSpans()
+	spans[1].Events = []sdktrace.Event{{Name: "cache.miss", Time: spans[1].StartTime}}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithIndicatorGlyphs(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Span Name:  child-span-1 ⚑")
+	must.StrNotContains(t, output, "Span Name:  root-span ⚑")
+	must.StrNotContains(t, output, "🔗")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithIndicatorGlyphs(true), printer.WithGlyphs("[E]", "[L]")))
+	must.StrContains(t, buf.String(), "Span Name:  child-span-1 [E]")
+}
+
+func TestPrintSpanTree_ServiceSummary(t *testing.T) {
+	spans := sampleSpans()
+	frontend := resource.NewSchemaless(semconv.ServiceName("frontend"))
+	api := resource.NewSchemaless(semconv.ServiceName("api"))
+	spans[0].Resource = frontend
+	spans[1].Resource = api
+	spans[2].Resource = api
+	spans[3].Resource = frontend
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithServiceSummary(true)))
+	must.StrContains(t, buf.String(), "services: 2 (frontend, api)")
+
+	spans[3].Resource = nil
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithServiceSummary(true)))
+	must.StrContains(t, buf.String(), "services: 3 (frontend, api, (unknown))")
+}
+
+func TestPrintSpanTree_FlagSection(t *testing.T) {
+	spans := sampleSpans()
+	isError := func(s tracetest.SpanStub) bool {
+		for _, attr := range s.Attributes {
+			if attr.Key == "error_code" {
+				return true
+			}
+		}
+		return false
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithFlagSection(isError)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Flagged spans:")
+	must.StrContains(t, output, "at root-span › child-span-2")
+	must.StrNotContains(t, output, "at root-span › child-span-1")
+	must.True(t, strings.Index(output, "Flagged spans:") < strings.Index(output, "Span Name:  root-span"))
+	must.Eq(t, 1, strings.Count(output, "Span Name:  child-span-2"))
+}
+
+func TestPrintSpanTree_SiblingRank(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("medium", 2, 1, 0, 20*ms),
+		newSpan("long", 3, 1, 0, 50*ms),
+		newSpan("short", 4, 1, 0, 5*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithSiblingRank(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "long (slowest of 3)")
+	must.StrContains(t, output, "medium (2nd of 3 by duration)")
+	must.StrContains(t, output, "short (3rd of 3 by duration)")
+	must.StrNotContains(t, output, "root (")
+}
+
+func TestPrintSpanTree_ExceptionDetails(t *testing.T) {
+	span := newSpan("charge", 1, 0, 0, time.Second)
+	span.Status = sdktrace.Status{Code: codes.Error, Description: "payment failed"}
+	span.Events = []sdktrace.Event{{
+		Name: "exception",
+		Time: span.StartTime,
+		Attributes: []attribute.KeyValue{
+			attribute.String("exception.type", "*errors.errorString"),
+			attribute.String("exception.message", "card declined"),
+			attribute.String("exception.stacktrace", "goroutine 1\nmain.charge()\nmain.main()\nruntime.main()\nruntime.goexit()\nextra.frame()\nlast.frame()"),
+		},
+	}}
+
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithExceptionDetails(true), printer.WithRenderer(r)))
+	output := buf.String()
+
+	must.StrContains(t, output, "\x1b[38;5;196m*errors.errorString: card declined")
+	must.StrContains(t, output, "main.charge()")
+	must.StrContains(t, output, "… (2 more lines)")
+	// The full stacktrace is only listed with the exception event.
+	must.Eq(t, 1, strings.Count(output, "last.frame()"))
+
+	span.Status = sdktrace.Status{}
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithExceptionDetails(true)))
+	must.StrNotContains(t, buf.String(), "Error:")
+}
+
+func TestPrintSpanTree_MaxEvents(t *testing.T) {
+	span := newSpan("batch", 1, 0, 0, time.Second)
+	for i := 5; i >= 1; i-- {
+		span.Events = append(span.Events, sdktrace.Event{
+			Name: fmt.Sprintf("event-%d", i),
+			Time: span.StartTime.Add(time.Duration(i) * time.Millisecond),
+		})
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithMaxEvents(2)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Events:")
+	must.StrContains(t, output, "event-1")
+	must.StrContains(t, output, "event-2")
+	must.StrNotContains(t, output, "event-3")
+	must.StrContains(t, output, "… 3 more events")
+}
+
+func TestPrintSpanTree_EpochOffset(t *testing.T) {
+	span := newSpan("checkout", 1, 0, 0, time.Second)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithEpochOffset(printer.EpochMillis)))
+
+	must.StrContains(t, buf.String(), fmt.Sprintf("(epoch: %d)", span.StartTime.UnixMilli()))
+	must.StrContains(t, buf.String(), "(epoch: 1704207845000)")
+}
+
+func TestPrintSpanTree_RootMarker(t *testing.T) {
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(), printer.WithRootMarker(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "◉ ROOT root-span")
+	must.Eq(t, 1, strings.Count(output, "◉ ROOT"))
+	must.StrNotContains(t, output, "◉ ROOT child-span")
+}
+
+func TestPrintSpanTree_OverlapMarker(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("fetch-a", 2, 1, 0, 40*ms),
+		newSpan("fetch-b", 3, 1, 10*ms, 50*ms),
+		newSpan("render", 4, 1, 50*ms, 90*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithOverlapMarker(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "fetch-a (concurrent with 1 sibling)")
+	must.StrContains(t, output, "fetch-b (concurrent with 1 sibling)")
+	must.StrContains(t, output, "render (serial)")
+	must.StrNotContains(t, output, "root (")
+}
+
+func TestPrintSpanTree_PageColumns(t *testing.T) {
+	var spans []tracetest.SpanStub
+	for i := byte(1); i <= 4; i++ {
+		spans = append(spans, newSpan(fmt.Sprintf("root-%d", i), i, 0, time.Duration(i)*time.Millisecond, time.Second))
+	}
+
+	var single, paged bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&single, spans))
+	must.NoError(t, printer.PrintSpanTree(&paged, spans, printer.WithPageColumns(2)))
+	output := paged.String()
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	// Each row of boxes holds one tree per column.
+	must.Eq(t, 2, strings.Count(lines[0], "╭"))
+	must.True(t, strings.Contains(lines[1], "root-1") && strings.Contains(lines[1], "root-2"))
+	must.StrContains(t, output, "root-3")
+	must.StrContains(t, output, "root-4")
+	must.Eq(t, strings.Count(single.String(), "\n")/2, len(lines))
+}
+
+func TestPrintSpanTree_PrematureCloseMarker(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("handler", 1, 0, 0, 100*ms),
+		newSpan("audit.write", 2, 1, 50*ms, 150*ms),
+		newSpan("cache.set", 3, 1, 10*ms, 20*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithPrematureCloseMarker(true)))
+
+	must.StrContains(t, buf.String(), "⚠ ended before child 'audit.write'")
+	must.Eq(t, 1, strings.Count(buf.String(), "ended before child"))
+}
+
+func TestPrintSpanTree_PrematureCloseMarkerUnfinished(t *testing.T) {
+	ms := time.Millisecond
+	parent := newSpan("handler", 1, 0, 0, 0)
+	parent.EndTime = time.Time{}
+	spans := []tracetest.SpanStub{
+		parent,
+		newSpan("c", 2, 1, 10*ms, 20*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithPrematureCloseMarker(true)))
+
+	must.StrContains(t, buf.String(), "End Time:  (unset)")
+	must.StrNotContains(t, buf.String(), "ended before child")
+}
+
+func TestPrintSpanTree_Status(t *testing.T) {
+	failed := newSpan("charge", 1, 0, 0, time.Second)
+	failed.Status = sdktrace.Status{Code: codes.Error, Description: "card declined"}
+	healthy := newSpan("lookup", 2, 1, 0, time.Millisecond)
+	unset := newSpan("log", 3, 1, time.Millisecond, 2*time.Millisecond)
+	healthy.Status = sdktrace.Status{Code: codes.Ok}
+
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{failed, healthy, unset}, printer.WithRenderer(r)))
+	output := buf.String()
+
+	must.StrContains(t, output, "\x1b[38;5;196mError: card declined")
+	must.StrContains(t, output, "\x1b[38;5;250mOk")
+	must.Eq(t, 2, strings.Count(output, "Status:"))
+}
+
+func TestPrintSpanTree_Events(t *testing.T) {
+	span := newSpan("charge", 1, 0, 0, time.Second)
+	span.Events = []sdktrace.Event{
+		{
+			Name: "exception",
+			Time: span.StartTime.Add(500 * time.Millisecond),
+			Attributes: []attribute.KeyValue{
+				attribute.String("exception.message", "card declined"),
+				attribute.String("exception.stacktrace", "main.charge()"),
+			},
+		},
+		{
+			Name:       "retry",
+			Time:       span.StartTime.Add(100 * time.Millisecond),
+			Attributes: []attribute.KeyValue{attribute.Int("attempt", 2)},
+		},
+	}
+
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithRenderer(r)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Events:")
+	must.StrContains(t, output, "• retry at 2024-01-02 15:04:05.100 UTC")
+	must.StrContains(t, output, "• attempt = 2")
+	must.StrContains(t, output, "\x1b[38;5;196m• exception at 2024-01-02 15:04:05.500 UTC")
+	must.StrContains(t, output, "\x1b[38;5;196m• exception.message = card declined")
+	must.StrContains(t, output, "main.charge()")
+	must.True(t, strings.Index(output, "retry") < strings.Index(output, "exception"))
+}
+
+func TestPrintSpanTree_Links(t *testing.T) {
+	linked := newSpan("enqueue", 9, 0, 0, time.Millisecond)
+	linked.SpanContext = linked.SpanContext.WithTraceID(trace.TraceID{0x11, 0x22, 0x33})
+
+	span := newSpan("consume", 1, 0, 0, time.Second)
+	span.Links = []sdktrace.Link{{
+		SpanContext: linked.SpanContext,
+		Attributes:  []attribute.KeyValue{attribute.String("messaging.operation", "receive")},
+	}}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}))
+	output := buf.String()
+
+	must.StrContains(t, output, "Links:")
+	must.StrContains(t, output, "• TraceID: "+linked.SpanContext.TraceID().String()+" SpanID: "+linked.SpanContext.SpanID().String())
+	must.StrContains(t, output, "• messaging.operation = receive")
+	must.Eq(t, 1, strings.Count(output, "Span Name:"))
+}
+
+// countingWriter fails every write after the first limit writes.
+type countingWriter struct {
+	limit, writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes > w.limit {
+		return 0, errors.New("connection reset")
+	}
+	return len(p), nil
+}
+
+func TestPrintSpanTree_WriteError(t *testing.T) {
+	var spans []tracetest.SpanStub
+	for i := byte(1); i <= 3; i++ {
+		spans = append(spans, newSpan(fmt.Sprintf("root-%d", i), i, 0, 0, time.Second))
+	}
+
+	w := &countingWriter{limit: 1}
+	err := printer.PrintSpanTree(w, spans)
+	must.ErrorContains(t, err, "connection reset")
+	must.Eq(t, 2, w.writes)
+
+	must.ErrorContains(t, printer.PrintSpanTree(failingWriter{}, spans), "disk full")
+	must.NoError(t, printer.PrintSpanTree(failingWriter{}, nil))
+}
+
+func TestPrintSpanTree_Kind(t *testing.T) {
+	client := newSpan("GET", 1, 0, 0, time.Second)
+	client.SpanKind = trace.SpanKindClient
+	internal := newSpan("parse", 2, 1, 0, time.Millisecond)
+	internal.SpanKind = trace.SpanKindInternal
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{client, internal}))
+	output := buf.String()
+
+	must.StrContains(t, output, "Kind:  Client")
+	must.Eq(t, 1, strings.Count(output, "Kind:"))
+}
+
+func TestPrintSpanTree_ShowResource(t *testing.T) {
+	parent := newSpan("checkout", 1, 0, 0, time.Second)
+	parent.Resource = resource.NewSchemaless(semconv.ServiceName("checkout"), semconv.HostName("host-a"))
+	child := newSpan("charge", 2, 1, 0, time.Millisecond)
+	child.Resource = resource.NewSchemaless(semconv.ServiceName("checkout"), semconv.HostName("host-b"))
+	spans := []tracetest.SpanStub{parent, child}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithShowResource(true)))
+	output := buf.String()
+
+	must.Eq(t, 1, strings.Count(output, "service.name"))
+	must.Less(t, strings.Index(output, "Span Name:"), strings.Index(output, "service.name"))
+	must.StrContains(t, output, "host-a")
+	must.StrContains(t, output, "host-b")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
+	must.StrNotContains(t, buf.String(), "Resource:")
+}
+
+func TestPrintSpanTree_Dropped(t *testing.T) {
+	span := newSpan("limited", 1, 0, 0, time.Second)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}))
+	must.StrNotContains(t, buf.String(), "Dropped:")
+
+	span.DroppedAttributes = 5
+	span.DroppedEvents = 1
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}))
+	must.StrContains(t, buf.String(), "Dropped: 5 attributes, 1 event")
+}
+
+func TestPrintSpanTree_RelativeTimes(t *testing.T) {
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*time.Millisecond),
+		newSpan("child", 2, 1, 12300*time.Microsecond, 50*time.Millisecond),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithRelativeTimes(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Start Time:  +0ms")
+	must.StrContains(t, output, "Start Time:  +12.3ms")
+	must.StrContains(t, output, "End Time:  +50.0ms")
+	must.StrContains(t, output, "Duration:  37.7ms")
+	must.StrNotContains(t, output, baseTime.Format("2006"))
+
+	// A span without a start time doesn't move the anchor
+	unstarted := newSpan("unstarted", 3, 1, 0, 60*time.Millisecond)
+	unstarted.StartTime = time.Time{}
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, append(spans, unstarted), printer.WithRelativeTimes(true)))
+	output = buf.String()
+
+	must.StrContains(t, output, "Start Time:  +12.3ms")
+	must.StrContains(t, output, "Start Time:  (unset)")
+	must.StrContains(t, output, "End Time:  +60.0ms")
+	must.StrNotContains(t, output, "h47m")
+}
+
+func TestPrintSpanTree_TimeFormat(t *testing.T) {
+	span := newSpan("root", 1, 0, 0, time.Second)
+	span.Events = []sdktrace.Event{{Name: "retry", Time: baseTime.Add(500 * time.Millisecond)}}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithTimeFormat("15:04:05")))
+	output := buf.String()
+
+	must.StrContains(t, output, "Start Time:  15:04:05")
+	must.StrContains(t, output, "End Time:  15:04:06")
+	must.StrContains(t, output, "retry at 15:04:05")
+	must.StrNotContains(t, output, "2024")
+}
+
+func TestPrintSpanTree_DurationFormat(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                         "0s",
+		850 * time.Nanosecond:     "850ns",
+		850 * time.Microsecond:    "850µs",
+		12300 * time.Microsecond:  "12.3ms",
+		999700 * time.Microsecond: "1.00s",
+		1500 * time.Millisecond:   "1.50s",
+		time.Hour + 2*time.Minute + 3004*time.Millisecond: "1h2m3s",
+	} {
+		var buf bytes.Buffer
+		must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{newSpan("op", 1, 0, 0, d)}))
+		must.StrContains(t, buf.String(), "Duration:  "+want+" ")
+	}
+
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, time.Second),
+		newSpan("child", 2, 1, 0, time.Millisecond),
+	}
+	format := printer.WithDurationFormat(func(time.Duration) string { return "DUR" })
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, format, printer.WithCriticalPath(true)))
+	output := buf.String()
+
+	must.Eq(t, 2, strings.Count(output, "Duration:  DUR"))
+	must.StrContains(t, output, "critical path: DUR (of DUR total)")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTreeCompact(&buf, spans, format))
+	must.StrContains(t, buf.String(), "child (DUR)")
+}
+
+func TestPrintSpanTree_TraceHeaders(t *testing.T) {
+	other := trace.TraceID{0x11, 0x22, 0x33}
+	inOther := func(s tracetest.SpanStub) tracetest.SpanStub {
+		s.SpanContext = s.SpanContext.WithTraceID(other)
+		if s.Parent.IsValid() {
+			s.Parent = s.Parent.WithTraceID(other)
+		}
+		return s
+	}
+	spans := []tracetest.SpanStub{
+		newSpan("first", 1, 0, 10*time.Millisecond, 50*time.Millisecond),
+		newSpan("first-child", 2, 1, 20*time.Millisecond, 30*time.Millisecond),
+		newSpan("first-grandchild", 3, 2, 20*time.Millisecond, 25*time.Millisecond),
+		inOther(newSpan("second", 4, 0, 0, 40*time.Millisecond)),
+		inOther(newSpan("second-child", 5, 4, 5*time.Millisecond, 15*time.Millisecond)),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
+	output := buf.String()
+
+	firstHeader := "Trace " + spans[0].SpanContext.TraceID().String() + " (3 spans)"
+	secondHeader := "Trace " + other.String() + " (2 spans)"
+	must.StrContains(t, output, firstHeader)
+	must.StrContains(t, output, secondHeader)
+	must.Less(t, strings.Index(output, firstHeader), strings.Index(output, secondHeader))
+
+	// Trace order follows start time even when siblings are sorted otherwise.
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithChildSort(printer.SortByName)))
+	output = buf.String()
+	must.Less(t, strings.Index(output, firstHeader), strings.Index(output, secondHeader))
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans[:3]))
+	must.StrNotContains(t, buf.String(), "Trace ")
+}
+
+func TestPrintSpanTree_Highlight(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(), printer.WithHighlight("WRONG"), printer.WithRenderer(r)))
+	output := buf.String()
+
+	must.StrContains(t, output, "\x1b[38;5;196m• error_code = \x1b[0m\x1b[38;5;196msomething_\x1b[0m\x1b[1;7mwrong\x1b[0m")
+	must.Eq(t, 1, strings.Count(output, "\x1b[1;7m"))
+
+	// Span names are searched too, and every match on a line is marked
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(), printer.WithHighlight("-"), printer.WithRenderer(r)))
+	must.StrContains(t, buf.String(), "\x1b[38;5;250mchild\x1b[0m\x1b[1;7m-\x1b[0m\x1b[38;5;250mspan\x1b[0m\x1b[1;7m-\x1b[0m\x1b[38;5;250m1\x1b[0m")
+}
+
+func TestPrintSpanTree_Indent(t *testing.T) {
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(), printer.WithIndent("| ")))
+	output := buf.String()
+
+	must.StrContains(t, output, "│ | ╭")
+	must.StrContains(t, output, "│ | │ Span Name:  child-span-1")
+	must.StrContains(t, output, "│ | │ | │ Span Name:  child-span-3")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTreeCompact(&buf, sampleSpans(), printer.WithIndent("| ")))
+	must.StrContains(t, buf.String(), "\n| child-span-1 (")
+	must.StrContains(t, buf.String(), "\n| | child-span-3 (")
+}
+
+func TestPrintSpanTree_MaxWidth(t *testing.T) {
+	spans := sampleSpans()
+	spans[3].Attributes = append(spans[3].Attributes, attribute.String("db.statement", strings.Repeat("SELECT * FROM orders ", 10)))
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithMaxWidth(40)))
+	output := buf.String()
+
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		must.LessEq(t, 40, lipgloss.Width(line), must.Sprint(line))
+	}
+	must.StrContains(t, output, "child-span-3")
+	must.StrContains(t, output, "orders")
+}
+
+func TestPrinter(t *testing.T) {
+	p := printer.NewPrinter(printer.WithRelativeTimes(true), printer.WithSummary(true))
+
+	early := []tracetest.SpanStub{
+		newSpan("early", 1, 0, 0, 10*time.Millisecond),
+	}
+	late := []tracetest.SpanStub{
+		newSpan("late", 1, 0, time.Hour, time.Hour+20*time.Millisecond),
+		newSpan("late-child", 2, 1, time.Hour+5*time.Millisecond, time.Hour+15*time.Millisecond),
+	}
+
+	var first, second bytes.Buffer
+	must.NoError(t, p.Print(&first, early))
+	must.NoError(t, p.Print(&second, late))
+
+	// Each call measures from its own spans
+	must.StrContains(t, first.String(), "summary: 1 span,")
+	must.StrContains(t, second.String(), "Start Time:  +0ms")
+	must.StrContains(t, second.String(), "Start Time:  +5.00ms")
+	must.StrContains(t, second.String(), "summary: 2 spans,")
+	must.StrNotContains(t, second.String(), "early")
+
+	var direct bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&direct, late, printer.WithRelativeTimes(true), printer.WithSummary(true)))
+	must.Eq(t, direct.String(), second.String())
+
+	var compact bytes.Buffer
+	must.NoError(t, p.PrintCompact(&compact, late))
+	must.StrContains(t, compact.String(), "late-child (10.0ms)")
+
+	// The other formats share the Printer's configuration
+	filtered := printer.NewPrinter(printer.WithNameFilter("late"), printer.WithAttributeSort(false))
+	late[0].Attributes = []attribute.KeyValue{attribute.String("z", "last"), attribute.String("a", "first")}
+	formats := map[string]func(io.Writer, []tracetest.SpanStub) error{
+		"json":     filtered.PrintJSON,
+		"html":     filtered.PrintHTML,
+		"markdown": filtered.PrintMarkdown,
+		"csv":      filtered.PrintCSV,
+	}
+	for name, write := range formats {
+		var buf bytes.Buffer
+		must.NoError(t, write(&buf, late), must.Sprint(name))
+		must.StrContains(t, buf.String(), "late", must.Sprint(name))
+		must.StrNotContains(t, buf.String(), "late-child", must.Sprint(name))
+	}
+
+	var markdown bytes.Buffer
+	must.NoError(t, filtered.PrintMarkdown(&markdown, late))
+	must.StrContains(t, markdown.String(), "  - z = last\n  - a = first\n")
+}
+
+func TestPrinter_Concurrent(t *testing.T) {
+	p := printer.NewPrinter(printer.WithMinDuration(time.Millisecond))
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 10*time.Millisecond),
+		newSpan("slow", 2, 1, 0, 5*time.Millisecond),
+		newSpan("blip", 3, 1, 5*time.Millisecond, 5*time.Millisecond+time.Microsecond),
+	}
+
+	formats := []func(io.Writer, []tracetest.SpanStub) error{
+		p.Print, p.PrintCompact, p.PrintTimeline,
+		p.PrintJSON, p.PrintHTML, p.PrintMarkdown, p.PrintCSV,
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		for _, write := range formats {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var buf bytes.Buffer
+				must.NoError(t, write(&buf, spans))
+				must.StrNotContains(t, buf.String(), "blip")
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestPrintSpanTree_InvalidDurations(t *testing.T) {
+	skewed := newSpan("skewed", 1, 0, time.Second, 0)
+	running := newSpan("running", 2, 0, 0, 0)
+	running.EndTime = time.Time{}
+	instant := newSpan("instant", 3, 0, 0, 0)
+
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{skewed, running, instant}, printer.WithRenderer(r)))
+	output := buf.String()
+
+	must.StrContains(t, output, "\x1b[38;5;196m(invalid: end before start)")
+	must.StrContains(t, output, "\x1b[38;5;250m0s")
+	must.StrNotContains(t, output, "-1.00s")
+	must.Eq(t, 2, strings.Count(output, "Duration:"))
+}
+
+func TestPrintSpanTree_UnsetTimes(t *testing.T) {
+	running := newSpan("running", 1, 0, 0, 0)
+	running.EndTime = time.Time{}
+	placeholder := newSpan("placeholder", 2, 0, 0, 0)
+	placeholder.StartTime, placeholder.EndTime = time.Time{}, time.Time{}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{running}, printer.WithEpochOffset(printer.EpochMillis)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Start Time:  2024-01-02 15:04:05.000 UTC (epoch: ")
+	must.StrContains(t, output, "End Time:  (unset)")
+	must.StrNotContains(t, output, "Duration:")
+	must.StrNotContains(t, output, "0001-01-01")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{placeholder}, printer.WithRelativeTimes(true)))
+	output = buf.String()
+
+	must.StrContains(t, output, "Start Time:  (unset)")
+	must.StrContains(t, output, "End Time:  (unset)")
+	must.StrNotContains(t, output, "Duration:")
+}
+
+func TestPrintSpanTree_Deterministic(t *testing.T) {
+	// render prints the same logical spans, recorded starting at start
+	render := func(start time.Time, opts ...printer.Option) string {
+		spans := []tracetest.SpanStub{
+			newSpan("checkout", 1, 0, 0, 30*time.Millisecond),
+			newSpan("charge", 2, 1, 5*time.Millisecond, 25*time.Millisecond),
+			newSpan("email", 3, 1, 5*time.Millisecond, 9*time.Millisecond),
+		}
+		spans[1].Attributes = []attribute.KeyValue{attribute.String("z", "last"), attribute.String("a", "first")}
+		spans[1].Events = []sdktrace.Event{{Name: "retry", Time: baseTime.Add(10 * time.Millisecond)}}
+		for i := range spans {
+			offset := start.Sub(baseTime)
+			spans[i].StartTime = spans[i].StartTime.Add(offset)
+			spans[i].EndTime = spans[i].EndTime.Add(offset)
+			for j := range spans[i].Events {
+				spans[i].Events[j].Time = spans[i].Events[j].Time.Add(offset)
+			}
+		}
+
+		r := lipgloss.NewRenderer(io.Discard)
+		r.SetColorProfile(termenv.ANSI256)
+
+		var buf bytes.Buffer
+		must.NoError(t, printer.PrintSpanTree(&buf, spans, append([]printer.Option{printer.WithRenderer(r)}, opts...)...))
+		return buf.String()
+	}
+
+	later := baseTime.Add(72*time.Hour + 123*time.Millisecond)
+	must.NotEq(t, render(baseTime), render(later))
+
+	// The preset overrides options given before it
+	deterministic := []printer.Option{
+		printer.WithAttributeSort(false),
+		printer.WithEpochOffset(printer.EpochNanos),
+		printer.WithDurationFormat(func(time.Duration) string { return "?" }),
+		printer.WithDeterministic(true),
+	}
+	output := render(baseTime, deterministic...)
+	must.Eq(t, output, render(later, deterministic...))
+	must.StrNotContains(t, output, "\x1b[")
+	must.StrContains(t, output, "Start Time:  +5.00ms")
+	must.StrContains(t, output, "Duration:  20.0ms")
+	must.StrContains(t, output, "• retry at +10.0ms")
+	must.Less(t, strings.Index(output, "• z = last"), strings.Index(output, "• a = first"))
+	must.Eq(t, output, render(baseTime, printer.WithDeterministic(false), printer.WithDeterministic(true)))
+}
+
+func TestPrintSpanTree_ErrorDetector(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	spans := sampleSpans()
+	spans[1].Attributes = append(spans[1].Attributes, attribute.String("level", "fatal"))
+
+	fatal := func(key string, val interface{}) bool {
+		return key == "level" && val == "fatal"
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithErrorDetector(fatal), printer.WithRenderer(r)))
+	output := buf.String()
+
+	must.StrContains(t, output, "\x1b[38;5;196m• level = fatal")
+	must.StrNotContains(t, output, "\x1b[38;5;196m• error_code")
+
+	// Without a detector the built-in check applies
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithRenderer(r)))
+	output = buf.String()
+
+	must.StrNotContains(t, output, "\x1b[38;5;196m• level = fatal")
+	must.StrContains(t, output, "\x1b[38;5;196m• error_code")
+}
@@ -0,0 +1,30 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// RenderRoots returns only the root span boxes, without recursing into their
+// children, each followed by a note counting the descendants beneath it. It
+// gives a quick overview of how many traces there are and what they start
+// with.
+func RenderRoots(spans []tracetest.SpanStub, opts ...Option) string {
+	cfg := newConfig(opts...)
+	tree := newSpanTree(cfg, filterSpans(cfg, spans))
+
+	// Render each root's box from a tree without children
+	bare := &spanTree{byID: tree.byID}
+
+	var out []string
+	for _, root := range tree.roots {
+		descendants := len(tree.descendants([]tracetest.SpanStub{root})) - 1
+		out = append(out,
+			buildSpanBox(cfg, bare, root, nil),
+			cfg.styles.value.Render(fmt.Sprintf("(%d descendants)", descendants)),
+		)
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,19 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderRoots(t *testing.T) {
+	output := printer.RenderRoots(sampleSpans())
+
+	must.StrContains(t, output, "Span Name:  root-span")
+	must.StrContains(t, output, "(3 descendants)")
+	must.StrNotContains(t, output, "child-span")
+	must.Eq(t, 1, strings.Count(output, "╭"))
+}
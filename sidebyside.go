@@ -0,0 +1,63 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// RenderSideBySide renders two span trees next to each other in boxes of
+// equal width, for comparing two runs of the same operation.
+//
+// Spans are matched by canonical key, as in RenderDiff, and matched spans
+// share a row. A span present on only one side leaves a blank row on the
+// other, so everything below it stays aligned.
+func RenderSideBySide(left, right []tracetest.SpanStub, opts ...Option) string {
+	cfg := newConfig(opts...)
+	leftTree := newSpanTree(cfg, filterSpans(cfg, left))
+	rightTree := newSpanTree(cfg, filterSpans(cfg, right))
+
+	cell := func(span *tracetest.SpanStub, depth int) string {
+		if span == nil {
+			return ""
+		}
+		entry := fmt.Sprintf("%s%s (%s)", strings.Repeat(cfg.Indent, depth), span.Name, formatSpanDuration(cfg, *span))
+		return cfg.styles.value.Render(entry)
+	}
+
+	var leftLines, rightLines []string
+	var walk func(leftKids, rightKids []tracetest.SpanStub, prefix string, depth int)
+	walk = func(leftKids, rightKids []tracetest.SpanStub, prefix string, depth int) {
+		for _, node := range pairByKey(leftKids, rightKids, prefix) {
+			leftLines = append(leftLines, cell(node.golden, depth))
+			rightLines = append(rightLines, cell(node.current, depth))
+
+			var lKids, rKids []tracetest.SpanStub
+			if node.golden != nil {
+				lKids = leftTree.childrenOf(*node.golden)
+			}
+			if node.current != nil {
+				rKids = rightTree.childrenOf(*node.current)
+			}
+			walk(lKids, rKids, node.key, depth+1)
+		}
+	}
+	walk(leftTree.roots, rightTree.roots, "", 0)
+
+	if len(leftLines) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, line := range append(leftLines, rightLines...) {
+		width = max(width, lipgloss.Width(line))
+	}
+	column := cfg.styles.box.Width(width + cfg.styles.box.GetHorizontalPadding())
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		column.Render(strings.Join(leftLines, "\n")),
+		column.Render(strings.Join(rightLines, "\n")),
+	)
+}
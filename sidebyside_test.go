@@ -0,0 +1,46 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderSideBySide(t *testing.T) {
+	left := []tracetest.SpanStub{
+		newSpan("checkout", 1, 0, 0, 100*time.Millisecond),
+		newSpan("cart.load", 2, 1, 0, 20*time.Millisecond),
+		newSpan("payment", 3, 1, 20*time.Millisecond, 90*time.Millisecond),
+	}
+	right := []tracetest.SpanStub{
+		newSpan("checkout", 1, 0, 0, 150*time.Millisecond),
+		newSpan("cart.load", 2, 1, 0, 20*time.Millisecond),
+		newSpan("fraud.check", 4, 1, 20*time.Millisecond, 60*time.Millisecond),
+		newSpan("payment", 3, 1, 60*time.Millisecond, 140*time.Millisecond),
+	}
+
+	output := printer.RenderSideBySide(left, right)
+	lines := strings.Split(output, "\n")
+
+	// Both columns share every row, so each line holds two equal boxes.
+	for _, line := range lines {
+		must.Eq(t, lipgloss.Width(lines[0]), lipgloss.Width(line))
+	}
+
+	var payment string
+	for _, line := range lines {
+		if strings.Contains(line, "payment") {
+			payment = line
+		}
+	}
+	must.Eq(t, 2, strings.Count(payment, "payment"), must.Sprintf("payment not aligned in:\n%s", output))
+	must.StrContains(t, output, "checkout (100ms)")
+	must.StrContains(t, output, "checkout (150ms)")
+	must.Eq(t, 1, strings.Count(output, "fraud.check"))
+}
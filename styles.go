@@ -0,0 +1,119 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// styleSet is the resolved collection of styles used for a single render,
+// derived from the package defaults and the active Config.
+type styleSet struct {
+	box            lipgloss.Style
+	criticalPath   lipgloss.Style
+	label          lipgloss.Style
+	value          lipgloss.Style
+	errorHighlight lipgloss.Style
+	errorBorder    lipgloss.TerminalColor
+	match          lipgloss.Style
+	bar            lipgloss.Style
+	added          lipgloss.Style
+	removed        lipgloss.Style
+	changed        lipgloss.Style
+	heat           []lipgloss.Style
+
+	// labelWidth is the minimum width labels are padded to.
+	labelWidth int
+}
+
+// newStyleSet resolves the styles for cfg.
+func newStyleSet(cfg *Config) styleSet {
+	s := styleSet{
+		box:            boxStyle,
+		criticalPath:   criticalPathStyle,
+		label:          labelStyle,
+		value:          valueStyle,
+		errorHighlight: errorHighlightStyle,
+		match:          matchStyle,
+		bar:            barStyle,
+		added:          addedStyle,
+		removed:        removedStyle,
+		changed:        changedStyle,
+		heat:           heatStyles,
+		labelWidth:     cfg.LabelWidth,
+	}
+
+	if cfg.BorderColor != "" {
+		s.box = s.box.BorderForeground(cfg.BorderColor)
+	}
+	if cfg.LabelColor != "" {
+		s.label = s.label.Foreground(cfg.LabelColor)
+	}
+	if cfg.ValueColor != "" {
+		s.value = s.value.Foreground(cfg.ValueColor)
+	}
+	if cfg.ErrorColor != "" {
+		s.errorHighlight = s.errorHighlight.Foreground(cfg.ErrorColor)
+	}
+	s.errorBorder = s.errorHighlight.GetForeground()
+	if cfg.ErrorBorderColor != "" {
+		s.errorBorder = cfg.ErrorBorderColor
+	}
+
+	if cfg.Renderer != nil {
+		s = s.withRenderer(cfg.Renderer)
+	}
+
+	if cfg.NoColor {
+		// An ASCII profile drops every color and text attribute while
+		// leaving layout (borders, padding) untouched.
+		r := lipgloss.NewRenderer(io.Discard)
+		r.SetColorProfile(termenv.Ascii)
+		s = s.withRenderer(r)
+	}
+
+	return s
+}
+
+// supportsColor reports whether w is a terminal that can display colors.
+func supportsColor(w io.Writer) bool {
+	return lipgloss.NewRenderer(w).ColorProfile() != termenv.Ascii
+}
+
+// withRenderer returns a copy of s with every style bound to r.
+func (s styleSet) withRenderer(r *lipgloss.Renderer) styleSet {
+	s.box = s.box.Renderer(r)
+	s.criticalPath = s.criticalPath.Renderer(r)
+	s.label = s.label.Renderer(r)
+	s.value = s.value.Renderer(r)
+	s.errorHighlight = s.errorHighlight.Renderer(r)
+	s.match = s.match.Renderer(r)
+	s.bar = s.bar.Renderer(r)
+	s.added = s.added.Renderer(r)
+	s.removed = s.removed.Renderer(r)
+	s.changed = s.changed.Renderer(r)
+	heat := make([]lipgloss.Style, len(s.heat))
+	for i, h := range s.heat {
+		heat[i] = h.Renderer(r)
+	}
+	s.heat = heat
+	return s
+}
+
+// joinLabelValue is a helper that renders "Label: Value" with distinct
+// styling for each portion. Labels are right-padded to the configured label
+// width so values line up in a column.
+func (s styleSet) joinLabelValue(label string, val interface{}) string {
+	return s.joinLabelStyled(label, s.value, val)
+}
+
+// joinLabelStyled is like joinLabelValue, but renders the value in style.
+func (s styleSet) joinLabelStyled(label string, style lipgloss.Style, val interface{}) string {
+	if pad := s.labelWidth - lipgloss.Width(label); pad > 0 {
+		label += strings.Repeat(" ", pad)
+	}
+	return s.label.Render(label) + "  " + style.Render(fmt.Sprintf("%v", val))
+}
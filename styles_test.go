@@ -0,0 +1,172 @@
+package printer_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestWithRenderer(t *testing.T) {
+	spans := sampleSpans()
+
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var first, second bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&first, spans, printer.WithRenderer(r)))
+	must.NoError(t, printer.PrintSpanTree(&second, spans, printer.WithRenderer(r)))
+
+	must.Eq(t, first.String(), second.String())
+	must.StrContains(t, first.String(), "\x1b[1;38;5;212mSpan Name:")
+	must.StrContains(t, first.String(), "\x1b[38;5;196m• error_code = something_wrong")
+
+	ascii := lipgloss.NewRenderer(io.Discard)
+	ascii.SetColorProfile(termenv.Ascii)
+
+	var plain bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&plain, spans, printer.WithRenderer(ascii)))
+	must.StrNotContains(t, plain.String(), "\x1b[")
+	must.StrContains(t, plain.String(), "╭")
+}
+
+func TestWithErrorColor(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(), printer.WithRenderer(r), printer.WithErrorColor("214")))
+
+	must.StrContains(t, buf.String(), "\x1b[38;5;214m• error_code = something_wrong")
+	must.StrNotContains(t, buf.String(), "\x1b[38;5;196m")
+}
+
+func TestPrintSpanTree_ErrorBorder(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	failed := newSpan("charge", 1, 0, 0, time.Second)
+	failed.Status.Code = codes.Error
+	ok := newSpan("lookup", 2, 0, 0, time.Second)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{failed}, printer.WithRenderer(r)))
+	must.StrContains(t, buf.String(), "\x1b[38;5;196m╭")
+	must.StrNotContains(t, buf.String(), "\x1b[38;5;63m╭")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{ok}, printer.WithRenderer(r)))
+	must.StrContains(t, buf.String(), "\x1b[38;5;63m╭")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{failed}, printer.WithRenderer(r), printer.WithErrorBorderColor("201")))
+	must.StrContains(t, buf.String(), "\x1b[38;5;201m╭")
+}
+
+func TestWithNoColor(t *testing.T) {
+	spans := sampleSpans()
+
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var plain bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&plain, spans, printer.WithRenderer(r), printer.WithNoColor(true)))
+	must.StrNotContains(t, plain.String(), "\x1b[")
+	must.StrContains(t, plain.String(), "╭")
+
+	// A buffer isn't a terminal, so colors are dropped without being asked.
+	t.Setenv("CLICOLOR_FORCE", "")
+	var detected bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&detected, spans))
+	must.StrNotContains(t, detected.String(), "\x1b[")
+	must.Eq(t, plain.String(), detected.String())
+
+	// Choosing colors explicitly wins over detection.
+	var colored bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&colored, spans, printer.WithRenderer(r), printer.WithNoColor(false)))
+	must.StrContains(t, colored.String(), "\x1b[")
+}
+
+func TestWithThemeColors(t *testing.T) {
+	spans := sampleSpans()
+
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var themed bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&themed, spans,
+		printer.WithRenderer(r),
+		printer.WithBorderColor("33"),
+		printer.WithLabelColor("120"),
+		printer.WithValueColor("231"),
+	))
+	output := themed.String()
+
+	must.StrContains(t, output, "\x1b[38;5;33m╭")
+	must.StrContains(t, output, "\x1b[1;38;5;120mSpan Name:")
+	must.StrContains(t, output, "\x1b[38;5;231mroot-span")
+	must.StrNotContains(t, output, "38;5;212m")
+
+	// Without color options the output matches the default appearance.
+	var plain, defaults bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&plain, spans, printer.WithRenderer(r)))
+	must.NoError(t, printer.PrintSpanTree(&defaults, spans, printer.WithRenderer(r), printer.WithLabelColor("")))
+	must.Eq(t, plain.String(), defaults.String())
+}
+
+func TestWithLabelWidth(t *testing.T) {
+	span := newSpan("checkout", 1, 0, 0, time.Second)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{span}, printer.WithLabelWidth(12)))
+
+	// valueColumn returns the column at which value starts on its line.
+	valueColumn := func(value string) int {
+		for _, line := range strings.Split(buf.String(), "\n") {
+			if i := strings.Index(line, value); i >= 0 {
+				return lipgloss.Width(line[:i])
+			}
+		}
+		t.Fatalf("%q not found", value)
+		return 0
+	}
+
+	name := valueColumn("checkout")
+	must.Eq(t, name, valueColumn(span.SpanContext.TraceID().String()))
+	must.Eq(t, name, valueColumn(span.SpanContext.SpanID().String()))
+	must.Eq(t, name, valueColumn("1.00s"))
+}
+
+func TestPrintSpanTree_Legend(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(),
+		printer.WithRenderer(r),
+		printer.WithLegend(true),
+		printer.WithLabelColor("120"),
+		printer.WithErrorBorderColor("201"),
+	))
+	output := buf.String()
+
+	must.StrContains(t, output, "Legend:")
+	must.StrContains(t, output, "\x1b[1;38;5;120mLabel")
+	must.StrContains(t, output, "\x1b[38;5;196merror")
+	must.StrContains(t, output, "\x1b[38;5;201m│\x1b[0m      failed span box border")
+	must.StrNotContains(t, output, "critical path")
+	must.Less(t, strings.Index(output, "root-span"), strings.Index(output, "Legend:"))
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans()))
+	must.StrNotContains(t, buf.String(), "Legend:")
+}
@@ -0,0 +1,91 @@
+package printer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var (
+	// svgHeatColors fill waterfall bars from the shortest spans to the
+	// longest, matching the minimap's heat styles.
+	svgHeatColors = []string{"#00afff", "#ffd700", "#ff8700"}
+
+	// svgErrorColor fills the bars of error spans.
+	svgErrorColor = "#ff0000"
+)
+
+// WriteSVG writes spans to w as an SVG waterfall: one row per span, in the
+// same depth-first order as PrintSpanTree, with a bar positioned and sized by
+// the span's time within the overall window. Bars are colored by duration,
+// or red for error spans, and labeled with the span's name and duration.
+// Hovering a bar shows its attributes.
+//
+// The image's width and row height are set by WithSVGSize.
+func WriteSVG(w io.Writer, spans []tracetest.SpanStub, opts ...Option) error {
+	cfg := newConfig(opts...)
+	spans = filterSpans(cfg, spans)
+	tree := newSpanTree(cfg, spans)
+	ordered := tree.descendants(tree.roots)
+
+	width, rowHeight := max(cfg.SVGWidth, 1), max(cfg.SVGRowHeight, 1)
+
+	windowStart := earliestStart(ordered)
+	var longest time.Duration
+	for _, s := range ordered {
+		d, _ := spanDuration(s)
+		longest = max(longest, d)
+	}
+	window := wallClock(ordered)
+
+	esc := html.EscapeString
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"monospace\" font-size=\"%d\">\n",
+		width, rowHeight*len(ordered), max(rowHeight*3/5, 1))
+	for i, s := range ordered {
+		duration, _ := spanDuration(s)
+
+		x, barWidth := 0.0, 1.0
+		if window > 0 && !s.StartTime.IsZero() {
+			x = float64(s.StartTime.Sub(windowStart)) / float64(window) * float64(width)
+			barWidth = max(float64(duration)/float64(window)*float64(width), 1)
+		}
+		y := i * rowHeight
+
+		color := svgErrorColor
+		if !isErrorSpan(cfg, s) {
+			color = svgHeatColor(duration, longest)
+		}
+
+		var title strings.Builder
+		title.WriteString(s.Name)
+		for _, attr := range s.Attributes {
+			fmt.Fprintf(&title, "\n%s = %s", attr.Key, formatAttributeValue(cfg, attr))
+		}
+
+		b.WriteString("<g>\n")
+		fmt.Fprintf(&b, "<title>%s</title>\n", esc(title.String()))
+		fmt.Fprintf(&b, "<rect x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"%d\" fill=\"%s\"/>\n", x, y, barWidth, rowHeight-2, color)
+		fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%d\">%s</text>\n", x+2, y+rowHeight*7/10,
+			esc(fmt.Sprintf("%s (%s)", s.Name, formatSpanDuration(cfg, s))))
+		b.WriteString("</g>\n")
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// svgHeatColor picks the heat color for d relative to the longest duration.
+func svgHeatColor(d, longest time.Duration) string {
+	if longest <= 0 {
+		return svgHeatColors[0]
+	}
+	i := int(float64(d) / float64(longest) * float64(len(svgHeatColors)))
+	return svgHeatColors[min(max(i, 0), len(svgHeatColors)-1)]
+}
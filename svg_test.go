@@ -0,0 +1,50 @@
+package printer_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestWriteSVG(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("GET /search?q=<a&b>", 1, 0, 0, 100*ms),
+		newSpan("db.query", 2, 1, 10*ms, 60*ms),
+		newSpan("render", 3, 1, 60*ms, 90*ms),
+	}
+	spans[1].Attributes = []attribute.KeyValue{attribute.String("db.statement", `SELECT * FROM t WHERE name = "x" & 1 < 2`)}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.WriteSVG(&buf, spans, printer.WithSVGSize(400, 10)))
+
+	dec := xml.NewDecoder(&buf)
+	var root xml.Name
+	rects := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		must.NoError(t, err)
+		if start, ok := tok.(xml.StartElement); ok {
+			if root.Local == "" {
+				root = start.Name
+				must.SliceContains(t, start.Attr, xml.Attr{Name: xml.Name{Local: "width"}, Value: "400"})
+			}
+			if start.Name.Local == "rect" {
+				rects++
+			}
+		}
+	}
+	must.Eq(t, "svg", root.Local)
+	must.Eq(t, len(spans), rects)
+}
@@ -0,0 +1,55 @@
+package printer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// RenderSwimlanes arranges spans into lanes, one per service (the
+// service.name resource attribute), to show handoffs between services. Each
+// lane is a box labeled with the service name that lists its spans by start
+// time, with each span's offset from the earliest start across all lanes.
+// Lanes are ordered by their earliest span.
+func RenderSwimlanes(spans []tracetest.SpanStub, opts ...Option) string {
+	cfg := newConfig(opts...)
+	spans = filterSpans(cfg, spans)
+	if len(spans) == 0 {
+		return ""
+	}
+
+	// Lanes are in time order whatever the configured sibling order
+	byStart := *cfg
+	byStart.ChildSort = SortByStartTime
+	ordered := slices.Clone(spans)
+	sortSiblings(&byStart, ordered)
+	start := earliestStart(ordered)
+
+	var services []string
+	lanes := make(map[string][]tracetest.SpanStub)
+	for _, s := range ordered {
+		name := serviceName(s)
+		if _, ok := lanes[name]; !ok {
+			services = append(services, name)
+		}
+		lanes[name] = append(lanes[name], s)
+	}
+
+	boxes := make([]string, len(services))
+	for i, service := range services {
+		lines := []string{cfg.styles.label.Render(service)}
+		for _, s := range lanes[service] {
+			offset := formatTime(cfg, s.StartTime)
+			if !s.StartTime.IsZero() {
+				offset = "+" + formatDuration(cfg, s.StartTime.Sub(start))
+			}
+			entry := fmt.Sprintf("%s (%s)", s.Name, formatSpanDuration(cfg, s))
+			lines = append(lines, childIndent+cfg.styles.value.Render(fmt.Sprintf("%-10s %s", offset, entry)))
+		}
+		boxes[i] = cfg.styles.box.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+	return strings.Join(boxes, "\n")
+}
@@ -0,0 +1,57 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestRenderSwimlanes(t *testing.T) {
+	spans := sampleSpans()
+	frontend := resource.NewSchemaless(semconv.ServiceName("frontend"))
+	api := resource.NewSchemaless(semconv.ServiceName("api"))
+	spans[0].Resource = frontend
+	spans[1].Resource = frontend
+	spans[2].Resource = api
+	spans[3].Resource = api
+
+	output := printer.RenderSwimlanes(spans)
+
+	frontendAt := strings.Index(output, "frontend")
+	apiAt := strings.Index(output, "api")
+	must.Positive(t, frontendAt)
+	must.True(t, frontendAt < apiAt)
+	must.Eq(t, 2, strings.Count(output, "╭"))
+
+	frontendLane, apiLane := output[frontendAt:apiAt], output[apiAt:]
+	must.StrContains(t, frontendLane, "root-span")
+	must.StrContains(t, frontendLane, "child-span-1")
+	must.StrNotContains(t, frontendLane, "child-span-2")
+	must.StrContains(t, apiLane, "child-span-2")
+	must.StrContains(t, apiLane, "child-span-3")
+	must.StrNotContains(t, apiLane, "root-span")
+}
+
+func TestRenderSwimlanes_ChildSort(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 5*ms, 10*ms),
+		newSpan("slow", 2, 1, 6*ms, 40*ms),
+		newSpan("early", 3, 0, 0, 2*ms),
+	}
+
+	output := printer.RenderSwimlanes(spans, printer.WithChildSort(printer.SortByDuration))
+
+	must.StrNotContains(t, output, "+-")
+	must.StrContains(t, output, "+0s        early")
+	must.StrContains(t, output, "+5.00ms    root")
+	must.Less(t, strings.Index(output, "slow"), strings.Index(output, "root"))
+	must.Less(t, strings.Index(output, "root"), strings.Index(output, "early"))
+}
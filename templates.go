@@ -0,0 +1,79 @@
+package printer
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TemplateData is the data available to header and footer templates.
+type TemplateData struct {
+	// SpanCount is the number of spans printed.
+	SpanCount int
+
+	// TraceCount is the number of distinct traces among them.
+	TraceCount int
+
+	// ErrorCount is the number of spans that failed.
+	ErrorCount int
+
+	// TotalDuration is the wall-clock time from the earliest span start to
+	// the latest span end.
+	TotalDuration time.Duration
+
+	// FormattedDuration is TotalDuration formatted as span durations are,
+	// following WithDurationFormat and WithDurationBuckets.
+	FormattedDuration string
+}
+
+// newTemplateData summarizes spans for templates.
+func newTemplateData(cfg *Config, spans []tracetest.SpanStub) TemplateData {
+	traceIDs := make(map[string]bool)
+	errors := 0
+	for _, s := range spans {
+		traceIDs[s.SpanContext.TraceID().String()] = true
+		if isErrorSpan(cfg, s) {
+			errors++
+		}
+	}
+	total := wallClock(spans)
+	return TemplateData{
+		SpanCount:         len(spans),
+		TraceCount:        len(traceIDs),
+		ErrorCount:        errors,
+		TotalDuration:     total,
+		FormattedDuration: formatDuration(cfg, total),
+	}
+}
+
+// parseTemplates parses cfg's header and footer templates, returning nil for
+// those that aren't set.
+func parseTemplates(cfg *Config) (header, footer *template.Template, err error) {
+	if cfg.HeaderTemplate != "" {
+		if header, err = template.New("header").Parse(cfg.HeaderTemplate); err != nil {
+			return nil, nil, err
+		}
+	}
+	if cfg.FooterTemplate != "" {
+		if footer, err = template.New("footer").Parse(cfg.FooterTemplate); err != nil {
+			return nil, nil, err
+		}
+	}
+	return header, footer, nil
+}
+
+// renderTemplate executes tmpl with data, returning nothing unless it
+// succeeds so a failed template doesn't leave partial output. A nil tmpl
+// renders nothing.
+func renderTemplate(tmpl *template.Template, data TemplateData) (string, error) {
+	if tmpl == nil {
+		return "", nil
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
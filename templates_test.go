@@ -0,0 +1,62 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTree_HeaderFooterTemplates(t *testing.T) {
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans(),
+		printer.WithHeaderTemplate("Spans: {{.SpanCount}}"),
+		printer.WithFooterTemplate("{{.TraceCount}} trace(s), {{.ErrorCount}} error(s)"),
+	))
+	output := buf.String()
+
+	must.True(t, strings.HasPrefix(output, "Spans: 4\n"))
+	must.True(t, strings.HasSuffix(output, "\n1 trace(s), 1 error(s)\n"), must.Sprint(output))
+}
+
+func TestPrintSpanTree_HeaderTemplateError(t *testing.T) {
+	// Execution errors are returned before anything is written
+	var buf bytes.Buffer
+	err := printer.PrintSpanTree(&buf, sampleSpans(), printer.WithHeaderTemplate("{{.Missing}}"))
+	must.ErrorContains(t, err, "Missing")
+	must.Eq(t, "", buf.String())
+
+	err = printer.PrintSpanTree(&buf, sampleSpans(), printer.WithFooterTemplate("{{.Missing}}"))
+	must.ErrorContains(t, err, "footer")
+	must.Eq(t, "", buf.String())
+
+	// Parse errors are returned by every render
+	p := printer.NewPrinter(printer.WithHeaderTemplate("{{.SpanCount"))
+	for range 2 {
+		must.ErrorContains(t, p.Print(&buf, sampleSpans()), "header")
+		must.Eq(t, "", buf.String())
+	}
+}
+
+func TestPrintSpanTree_TemplateDuration(t *testing.T) {
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 1500*time.Millisecond),
+		newSpan("child", 2, 1, 100*time.Millisecond, 200*time.Millisecond),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithHeaderTemplate("took {{.FormattedDuration}} ({{.TotalDuration}})")))
+	must.True(t, strings.HasPrefix(buf.String(), "took 1.50s (1.5s)\n"), must.Sprint(buf.String()))
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans,
+		printer.WithHeaderTemplate("took {{.FormattedDuration}}"),
+		printer.WithDurationFormat(func(d time.Duration) string { return d.Round(time.Second).String() }),
+	))
+	must.True(t, strings.HasPrefix(buf.String(), "took 2s\n"), must.Sprint(buf.String()))
+}
@@ -0,0 +1,140 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	// defaultTimelineWidth is the number of characters the timeline bars
+	// span unless set with WithTimelineWidth.
+	defaultTimelineWidth = 80
+
+	// childWaterfallWidth is the number of characters the bars of a span's
+	// inline child waterfall span.
+	childWaterfallWidth = 30
+)
+
+// timelineRow is a single span's row in the timeline view.
+type timelineRow struct {
+	label string
+	span  tracetest.SpanStub
+}
+
+// PrintSpanTreeTimeline writes spans to w as a horizontal waterfall. Each
+// span gets one row, ordered depth-first, with a bar positioned and scaled by
+// its start and end relative to the overall window covered by spans, scaled
+// to the width set by WithTimelineWidth.
+//
+// It returns the first error encountered writing to w.
+func PrintSpanTreeTimeline(w io.Writer, spans []tracetest.SpanStub, opts ...Option) error {
+	return NewPrinter(opts...).PrintTimeline(w, spans)
+}
+
+// PrintTimeline writes spans to w as PrintSpanTreeTimeline does, using p's
+// configuration.
+func (p *Printer) PrintTimeline(w io.Writer, spans []tracetest.SpanStub) error {
+	cfg := writerConfig(p.cfg, w)
+	spans = filterSpans(cfg, spans)
+	if len(spans) == 0 {
+		return nil
+	}
+
+	tree := newSpanTree(cfg, spans)
+
+	// Collect rows depth-first so the hierarchy is preserved
+	var rows []timelineRow
+	var walk func(span tracetest.SpanStub, depth int)
+	walk = func(span tracetest.SpanStub, depth int) {
+		rows = append(rows, timelineRow{
+			label: strings.Repeat(cfg.Indent, depth) + span.Name,
+			span:  span,
+		})
+		for _, child := range tree.childrenOf(span) {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range tree.roots {
+		walk(root, 0)
+	}
+
+	labelWidth := 0
+	for _, row := range rows {
+		labelWidth = max(labelWidth, lipgloss.Width(row.label))
+	}
+
+	windowStart := earliestStart(spans)
+	window := wallClock(spans)
+	width := cfg.TimelineWidth
+
+	out := &lineWriter{w: w}
+	for _, row := range rows {
+		offset, length := barExtent(row.span, windowStart, window, width, cfg.MinBarWidth)
+		bar := strings.Repeat(" ", offset) +
+			cfg.styles.bar.Render(strings.Repeat("█", length)) +
+			strings.Repeat(" ", width-offset-length)
+
+		label := cfg.styles.label.Render(row.label + strings.Repeat(" ", labelWidth-lipgloss.Width(row.label)))
+		duration := cfg.styles.value.Render(formatSpanDuration(cfg, row.span))
+		out.println(fmt.Sprintf("%s │%s│ %s", label, bar, duration))
+	}
+	return out.err
+}
+
+// childWaterfall returns one row per child of span, each with a bar offset
+// and scaled within span's own window, or nil when span has no children.
+func childWaterfall(cfg *Config, span tracetest.SpanStub, children []tracetest.SpanStub) []string {
+	if len(children) == 0 {
+		return nil
+	}
+
+	labelWidth := 0
+	for _, child := range children {
+		labelWidth = max(labelWidth, lipgloss.Width(child.Name))
+	}
+
+	lines := []string{cfg.styles.label.Render("Child Timing:")}
+	for _, child := range children {
+		window, _ := spanDuration(span)
+		offset, length := barExtent(child, span.StartTime, window, childWaterfallWidth, cfg.MinBarWidth)
+		bar := strings.Repeat(" ", offset) +
+			cfg.styles.bar.Render(strings.Repeat("█", length)) +
+			strings.Repeat(" ", childWaterfallWidth-offset-length)
+
+		label := cfg.styles.value.Render(child.Name + strings.Repeat(" ", labelWidth-lipgloss.Width(child.Name)))
+		lines = append(lines, fmt.Sprintf("%s%s │%s│", childIndent, label, bar))
+	}
+	return lines
+}
+
+// barExtent returns the character offset and length of span's bar within a
+// timeline of the given width covering window from windowStart. The bar is
+// widened to minWidth, shifting left if needed to stay within the timeline.
+// A span that hasn't ended runs to the end of the timeline, and one with no
+// start time has no bar.
+func barExtent(span tracetest.SpanStub, windowStart time.Time, window time.Duration, width, minWidth int) (offset, length int) {
+	if span.StartTime.IsZero() {
+		return 0, 0
+	}
+	end := span.EndTime
+	if end.IsZero() {
+		end = windowStart.Add(window)
+	}
+	if window > 0 {
+		scale := float64(width) / float64(window)
+		offset = int(float64(span.StartTime.Sub(windowStart))*scale + 0.5)
+		length = int(float64(end.Sub(windowStart))*scale+0.5) - offset
+	}
+
+	offset = min(max(offset, 0), width)
+	length = min(max(length, minWidth, 0), width)
+	if offset+length > width {
+		offset = width - length
+	}
+	return offset, length
+}
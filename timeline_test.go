@@ -0,0 +1,130 @@
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+// timelineBar returns the bar portion of the timeline row for name.
+func timelineBar(t *testing.T, output, name string) string {
+	t.Helper()
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), name+" ") {
+			parts := strings.Split(line, "│")
+			must.SliceLen(t, 3, parts)
+			return parts[1]
+		}
+	}
+	t.Fatalf("no timeline row for %q in:\n%s", name, output)
+	return ""
+}
+
+func TestPrintSpanTreeTimeline_MinBarWidth(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("instant", 2, 1, 50*ms, 50*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeTimeline(&buf, spans))
+	must.StrNotContains(t, timelineBar(t, buf.String(), "instant"), "█")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTreeTimeline(&buf, spans, printer.WithMinBarWidth(1)))
+	output := buf.String()
+
+	must.Eq(t, 1, strings.Count(timelineBar(t, output, "instant"), "█"))
+	must.Eq(t, 80, strings.Count(timelineBar(t, output, "root"), "█"))
+}
+
+func TestPrintSpanTree_ChildWaterfall(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 300*ms),
+		newSpan("early", 2, 1, 0, 100*ms),
+		newSpan("late", 3, 1, 150*ms, 300*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithChildWaterfall(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Child Timing:")
+
+	// Child waterfall bars sit between "│" separators after the label
+	// (and inside the enclosing box's own borders).
+	barOf := func(name string) string {
+		for _, line := range strings.Split(output, "\n") {
+			if i := strings.Index(line, name+" "); i >= 0 && strings.Count(line, "│") == 4 {
+				return strings.Split(line[i:], "│")[1]
+			}
+		}
+		t.Fatalf("no waterfall row for %q", name)
+		return ""
+	}
+
+	early, late := barOf("early"), barOf("late")
+	must.StrHasPrefix(t, "█", early)
+	must.StrHasSuffix(t, "█", late)
+	must.True(t, strings.Index(early, "█") < strings.Index(late, "█"))
+	must.Eq(t, 10, strings.Count(early, "█"))
+	must.Eq(t, 15, strings.Count(late, "█"))
+}
+
+func TestPrintSpanTreeTimeline_Width(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("long", 2, 1, 0, 60*ms),
+		newSpan("short", 3, 1, 60*ms, 80*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeTimeline(&buf, spans))
+	output := buf.String()
+
+	long, short := timelineBar(t, output, "long"), timelineBar(t, output, "short")
+	must.Greater(t, strings.Count(short, "█"), strings.Count(long, "█"))
+	must.Eq(t, 80, len([]rune(long)))
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTreeTimeline(&buf, spans, printer.WithTimelineWidth(40)))
+	output = buf.String()
+
+	must.Eq(t, 40, len([]rune(timelineBar(t, output, "root"))))
+	must.Eq(t, 24, strings.Count(timelineBar(t, output, "long"), "█"))
+	must.Eq(t, 8, strings.Count(timelineBar(t, output, "short"), "█"))
+}
+
+func TestPrintSpanTreeTimeline_WriteError(t *testing.T) {
+	w := &countingWriter{limit: 1}
+	must.ErrorContains(t, printer.PrintSpanTreeTimeline(w, sampleSpans()), "connection reset")
+	must.Eq(t, 2, w.writes)
+}
+
+func TestPrintSpanTreeTimeline_Unfinished(t *testing.T) {
+	ms := time.Millisecond
+	pending := newSpan("pending", 2, 1, 50*ms, 0)
+	pending.EndTime = time.Time{}
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		pending,
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTreeTimeline(&buf, spans, printer.WithTimelineWidth(20)))
+	output := buf.String()
+
+	must.StrContains(t, output, "│ unfinished")
+	must.StrNotContains(t, output, "-2562047h")
+	must.Eq(t, strings.Repeat(" ", 10)+strings.Repeat("█", 10), timelineBar(t, output, "pending"))
+	must.Eq(t, strings.Repeat("█", 20), timelineBar(t, output, "root"))
+}
@@ -0,0 +1,228 @@
+package printer
+
+import (
+	"slices"
+	"sort"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// spanTree is the parent → children view of a set of spans that the
+// renderers walk.
+type spanTree struct {
+	// byID maps SpanID → SpanStub for quick lookups.
+	byID map[string]tracetest.SpanStub
+
+	// children maps a parent SpanID to its direct children, sorted by
+	// start time. Malformed input can make it cyclic, so walks over it must
+	// track the spans they have visited.
+	children map[string][]tracetest.SpanStub
+
+	// acyclic is children without the edges that close a cycle, which is
+	// what childrenOf returns. It is children itself when there are no
+	// cycles.
+	acyclic map[string][]tracetest.SpanStub
+
+	// roots holds the spans with no valid parent, along with orphans whose
+	// parent isn't among the spans, sorted by start time.
+	roots []tracetest.SpanStub
+
+	// duplicates lists, in input order, the SpanIDs shared by more than one
+	// span. byID keeps only the last span with each of them.
+	duplicates []string
+}
+
+// newSpanTree organizes spans into a spanTree, ordering siblings as
+// configured by cfg.
+func newSpanTree(cfg *Config, spans []tracetest.SpanStub) *spanTree {
+	t := &spanTree{
+		byID:     make(map[string]tracetest.SpanStub, len(spans)),
+		children: make(map[string][]tracetest.SpanStub),
+	}
+
+	// Build a map of SpanID → SpanStub for quick lookups, noting IDs that
+	// more than one span claims
+	for _, s := range spans {
+		id := s.SpanContext.SpanID().String()
+		if _, ok := t.byID[id]; ok && !slices.Contains(t.duplicates, id) {
+			t.duplicates = append(t.duplicates, id)
+		}
+		t.byID[id] = s
+	}
+
+	// Build a parent → slice of children map
+	for _, s := range spans {
+		if parentID := s.Parent.SpanID().String(); s.Parent.SpanID().IsValid() {
+			t.children[parentID] = append(t.children[parentID], s)
+		}
+	}
+
+	// Sort children by start time for stable ordering
+	for pid := range t.children {
+		sortSiblings(cfg, t.children[pid])
+	}
+
+	// Identify the root spans (i.e., those with no valid parent), promoting
+	// spans whose parent wasn't captured so they aren't lost.
+	for _, s := range spans {
+		if !s.Parent.SpanID().IsValid() || t.isOrphan(s) {
+			t.roots = append(t.roots, s)
+		}
+	}
+
+	// Sort roots by start time for stable ordering
+	sortSiblings(cfg, t.roots)
+
+	t.breakCycles(cfg, spans)
+
+	return t
+}
+
+// breakCycles finds the edges of t.children that close a cycle and sets
+// t.acyclic to t.children without them. Spans in a cycle have no root above
+// them, so the earliest span of each such cycle is promoted to a root; its
+// parent's edge to it is the one removed.
+func (t *spanTree) breakCycles(cfg *Config, spans []tracetest.SpanStub) {
+	reached := make(map[string]bool, len(spans))
+	onPath := make(map[string]bool)
+	backEdges := make(map[string]map[string]bool)
+
+	var visit func(span tracetest.SpanStub)
+	visit = func(span tracetest.SpanStub) {
+		id := span.SpanContext.SpanID().String()
+		reached[id] = true
+		onPath[id] = true
+		for _, child := range t.children[id] {
+			childID := child.SpanContext.SpanID().String()
+			switch {
+			case onPath[childID]:
+				if backEdges[id] == nil {
+					backEdges[id] = make(map[string]bool)
+				}
+				backEdges[id][childID] = true
+			case !reached[childID]:
+				visit(child)
+			}
+		}
+		delete(onPath, id)
+	}
+	for _, root := range t.roots {
+		visit(root)
+	}
+
+	// Whatever wasn't reached from a root hangs off a cycle
+	var unreached []tracetest.SpanStub
+	for _, s := range spans {
+		if !reached[s.SpanContext.SpanID().String()] {
+			unreached = append(unreached, s)
+		}
+	}
+	sortSiblings(cfg, unreached)
+	for _, s := range unreached {
+		if !reached[s.SpanContext.SpanID().String()] {
+			t.roots = append(t.roots, s)
+			visit(s)
+		}
+	}
+	sortSiblings(cfg, t.roots)
+
+	if len(backEdges) == 0 {
+		t.acyclic = t.children
+		return
+	}
+	t.acyclic = make(map[string][]tracetest.SpanStub, len(t.children))
+	for pid, children := range t.children {
+		for _, child := range children {
+			if !backEdges[pid][child.SpanContext.SpanID().String()] {
+				t.acyclic[pid] = append(t.acyclic[pid], child)
+			}
+		}
+	}
+}
+
+// sortSiblings orders spans by cfg.ChildSort, falling back to start time.
+// Ties keep their input order, or with cfg.StableSiblingOrder are broken by
+// name and then SpanID so the result doesn't depend on the order spans were
+// collected in.
+func sortSiblings(cfg *Config, spans []tracetest.SpanStub) {
+	sort.SliceStable(spans, func(i, j int) bool {
+		a, b := spans[i], spans[j]
+		switch cfg.ChildSort {
+		case SortByEndTime:
+			if !a.EndTime.Equal(b.EndTime) {
+				return a.EndTime.After(b.EndTime)
+			}
+		case SortByDuration:
+			da, _ := spanDuration(a)
+			if db, _ := spanDuration(b); da != db {
+				return da > db
+			}
+		case SortByName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		}
+		if !a.StartTime.Equal(b.StartTime) || !cfg.StableSiblingOrder {
+			return a.StartTime.Before(b.StartTime)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.SpanContext.SpanID().String() < b.SpanContext.SpanID().String()
+	})
+}
+
+// isOrphan reports whether span has a parent that isn't in the tree.
+func (t *spanTree) isOrphan(span tracetest.SpanStub) bool {
+	if !span.Parent.SpanID().IsValid() {
+		return false
+	}
+	_, ok := t.byID[span.Parent.SpanID().String()]
+	return !ok
+}
+
+// childrenOf returns the direct children of span, leaving out any child that
+// would close a cycle.
+func (t *spanTree) childrenOf(span tracetest.SpanStub) []tracetest.SpanStub {
+	return t.acyclic[span.SpanContext.SpanID().String()]
+}
+
+// traces groups the roots by TraceID. Groups are ordered by the start time of
+// their earliest root, whatever the configured sibling order, and roots keep
+// their sibling order within a group. Groups that start together keep the
+// order of their first root.
+func (t *spanTree) traces() [][]tracetest.SpanStub {
+	var groups [][]tracetest.SpanStub
+	index := make(map[string]int)
+	for _, root := range t.roots {
+		traceID := root.SpanContext.TraceID().String()
+		i, ok := index[traceID]
+		if !ok {
+			i = len(groups)
+			index[traceID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], root)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return earliestStart(groups[i]).Before(earliestStart(groups[j]))
+	})
+	return groups
+}
+
+// descendants returns every span below roots, depth-first, including the
+// roots themselves.
+func (t *spanTree) descendants(roots []tracetest.SpanStub) []tracetest.SpanStub {
+	var out []tracetest.SpanStub
+	var walk func(span tracetest.SpanStub)
+	walk = func(span tracetest.SpanStub) {
+		out = append(out, span)
+		for _, child := range t.childrenOf(span) {
+			walk(child)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	return out
+}
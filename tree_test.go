@@ -0,0 +1,201 @@
+package printer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	printer "github.com/picatz/otel-tracetest-printer"
+)
+
+func TestPrintSpanTree_StableSiblingOrder(t *testing.T) {
+	root := newSpan("root", 1, 0, 0, 10)
+	zeta := newSpan("zeta", 2, 1, 5, 10)
+	alpha := newSpan("alpha", 3, 1, 5, 10)
+
+	render := func(spans ...tracetest.SpanStub) string {
+		var buf bytes.Buffer
+		must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithStableSiblingOrder(true)))
+		return buf.String()
+	}
+
+	first := render(root, zeta, alpha)
+	second := render(alpha, root, zeta)
+
+	must.Eq(t, first, second)
+	must.True(t, strings.Index(first, "alpha") < strings.Index(first, "zeta"))
+}
+
+func TestPrintSpanTree_ChildSort(t *testing.T) {
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*time.Millisecond),
+		newSpan("short", 2, 1, 0, 10*time.Millisecond),
+		newSpan("long", 3, 1, 10*time.Millisecond, 80*time.Millisecond),
+		newSpan("medium", 4, 1, 20*time.Millisecond, 70*time.Millisecond),
+	}
+
+	order := func(opts ...printer.Option) []int {
+		var buf bytes.Buffer
+		must.NoError(t, printer.PrintSpanTree(&buf, spans, opts...))
+		output := buf.String()
+		return []int{
+			strings.Index(output, "Span Name:  short"),
+			strings.Index(output, "Span Name:  medium"),
+			strings.Index(output, "Span Name:  long"),
+		}
+	}
+
+	byStart := order()
+	must.True(t, byStart[0] < byStart[2] && byStart[2] < byStart[1])
+
+	byDuration := order(printer.WithChildSort(printer.SortByDuration))
+	must.True(t, byDuration[2] < byDuration[1] && byDuration[1] < byDuration[0])
+
+	byEnd := order(printer.WithChildSort(printer.SortByEndTime))
+	must.True(t, byEnd[2] < byEnd[1] && byEnd[1] < byEnd[0])
+
+	byName := order(printer.WithChildSort(printer.SortByName))
+	must.True(t, byName[2] < byName[1] && byName[1] < byName[0])
+}
+
+func TestPrintSpanTree_DuplicateSpanIDs(t *testing.T) {
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, time.Second),
+		newSpan("first", 2, 1, 0, 100*time.Millisecond),
+		newSpan("second", 2, 1, 200*time.Millisecond, 300*time.Millisecond),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans))
+	output := buf.String()
+
+	id := spans[1].SpanContext.SpanID().String()
+	must.True(t, strings.HasPrefix(output, "warning: duplicate SpanID "+id+" encountered, output may be incomplete\n"))
+	must.Eq(t, 1, strings.Count(output, "warning:"))
+	must.StrContains(t, output, "Span Name:  root")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithShortIDs(true)))
+	must.StrContains(t, buf.String(), "warning: duplicate SpanID …"+id[len(id)-8:]+" encountered")
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, append(spans, newSpan("third", 1, 0, 0, time.Second)), printer.WithSnapshotMode()))
+	must.True(t, strings.HasPrefix(buf.String(), "warning: 2 duplicate SpanIDs encountered, output may be incomplete\n"))
+	must.StrNotContains(t, buf.String(), id)
+
+	buf.Reset()
+	must.NoError(t, printer.PrintSpanTree(&buf, sampleSpans()))
+	must.StrNotContains(t, buf.String(), "warning:")
+}
+
+func TestPrintSpanTree_DeepChain(t *testing.T) {
+	const depth = 5000
+
+	spans := make([]tracetest.SpanStub, depth)
+	for i := range spans {
+		spans[i] = tracetest.SpanStub{
+			Name: fmt.Sprintf("level-%d", i),
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: trace.TraceID{1},
+				SpanID:  spanIDFor(i + 1),
+			}),
+			StartTime: baseTime.Add(time.Duration(i) * time.Microsecond),
+			EndTime:   baseTime.Add(time.Second - time.Duration(i)*time.Microsecond),
+		}
+		if i > 0 {
+			spans[i].Parent = spans[i-1].SpanContext
+		}
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithCriticalPath(true), printer.WithTruncationDetection(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "level-0")
+	must.StrContains(t, output, "level-49")
+	must.StrNotContains(t, output, "level-50 ")
+	must.StrContains(t, output, "nesting limit reached: (4950 spans, 1.00s, 0 errors) not shown")
+	must.StrContains(t, output, "critical path:")
+}
+
+// spanIDFor encodes n as a span ID.
+func spanIDFor(n int) trace.SpanID {
+	var id trace.SpanID
+	binary.BigEndian.PutUint64(id[:], uint64(n))
+	return id
+}
+
+func TestPrintSpanTree_CollapsedSummary(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 500*ms),
+		newSpan("service", 2, 1, 0, 400*ms),
+		newSpan("db", 3, 2, 10*ms, 200*ms),
+		newSpan("db.retry", 4, 3, 20*ms, 150*ms),
+		newSpan("cache", 5, 2, 250*ms, 350*ms),
+	}
+	spans[3].Attributes = []attribute.KeyValue{attribute.String("error", "timeout")}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithMaxNesting(2)))
+	output := buf.String()
+
+	must.StrContains(t, output, "service")
+	must.StrNotContains(t, output, "db.retry")
+	must.StrContains(t, output, "(3 spans, 340ms, 1 error)")
+}
+
+func TestPrintSpanTree_Orphans(t *testing.T) {
+	root := newSpan("checkout", 1, 0, 0, time.Second)
+	orphan := newSpan("payment.callback", 3, 2, 500*time.Millisecond, 800*time.Millisecond)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{root, orphan}))
+	output := buf.String()
+
+	must.StrContains(t, output, "checkout")
+	must.StrContains(t, output, "payment.callback")
+	must.StrContains(t, output, fmt.Sprintf("(orphaned: parent %s not captured)", orphan.Parent.SpanID()))
+	must.Eq(t, 1, strings.Count(output, "orphaned"))
+}
+
+func TestPrintSpanTree_Cycle(t *testing.T) {
+	a := newSpan("a", 1, 2, 0, time.Second)
+	b := newSpan("b", 2, 1, 100*time.Millisecond, 900*time.Millisecond)
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, []tracetest.SpanStub{a, b}, printer.WithCriticalPath(true), printer.WithDepthHistogram(true)))
+	output := buf.String()
+
+	must.StrContains(t, output, "Span Name:  a")
+	must.StrContains(t, output, "Span Name:  b")
+	must.StrContains(t, output, "↺ a (cycle detected)")
+	must.StrNotContains(t, output, "orphaned")
+	must.StrContains(t, output, "depth histogram: L0=1 L1=1")
+}
+
+func TestPrintSpanTree_MaxDepth(t *testing.T) {
+	ms := time.Millisecond
+	spans := []tracetest.SpanStub{
+		newSpan("root", 1, 0, 0, 100*ms),
+		newSpan("child", 2, 1, 10*ms, 90*ms),
+		newSpan("grandchild", 3, 2, 20*ms, 80*ms),
+		newSpan("great-grandchild", 4, 3, 30*ms, 70*ms),
+	}
+
+	var buf bytes.Buffer
+	must.NoError(t, printer.PrintSpanTree(&buf, spans, printer.WithMaxDepth(1)))
+	output := buf.String()
+
+	must.StrContains(t, output, "child")
+	must.StrNotContains(t, output, "grandchild")
+	must.StrContains(t, output, "… (2 spans, 60.0ms, 0 errors) hidden (max depth reached)")
+}